@@ -8,6 +8,7 @@ import (
 	"github.com/defenseunicorns/zarf/src/config/lang"
 	"github.com/defenseunicorns/zarf/src/internal/cluster"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -47,7 +48,20 @@ var (
 		Aliases: []string{"l"},
 		Short:   lang.CmdConnectListShort,
 		Run: func(cmd *cobra.Command, args []string) {
-			cluster.NewClusterOrDie().PrintConnectTable()
+			validateOutputFormat()
+
+			if outputFormat == "" {
+				cluster.NewClusterOrDie().PrintConnectTable()
+				return
+			}
+
+			connectStrings, err := cluster.NewClusterOrDie().GetConnectStrings()
+			if err != nil {
+				message.Fatal(err, lang.CmdConnectListErrGet)
+			}
+			if err := utils.PrintStructured(outputFormat, connectStrings); err != nil {
+				message.Fatal(err, err.Error())
+			}
 		},
 	}
 )
@@ -62,4 +76,6 @@ func init() {
 	connectCmd.Flags().IntVar(&connectLocalPort, "local-port", 0, lang.CmdConnectFlagLocalPort)
 	connectCmd.Flags().IntVar(&connectRemotePort, "remote-port", 0, lang.CmdConnectFlagRemotePort)
 	connectCmd.Flags().BoolVar(&cliOnly, "cli-only", false, lang.CmdConnectFlagCliOnly)
+
+	connectListCmd.Flags().StringVar(&outputFormat, "output", "", lang.CmdConnectListFlagOutput)
 }
@@ -78,6 +78,11 @@ var destroyCmd = &cobra.Command{
 			// If Zarf didn't deploy the cluster, only delete the ZarfNamespace
 			c.DeleteZarfNamespace()
 
+			// Remove the Zarf CRDs (if installed) so a later init starts with the state in secrets again
+			if err := c.DeleteZarfCRDs(); err != nil {
+				message.Error(err, lang.CmdDestroyErrCRDs)
+			}
+
 			// Remove zarf agent labels and secrets from namespaces Zarf doesn't manage
 			c.StripZarfLabelsAndSecretsFromNamespaces()
 		}
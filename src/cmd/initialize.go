@@ -146,6 +146,12 @@ func validateInitFlags() error {
 			return fmt.Errorf(lang.CmdInitErrValidateRegistry)
 		}
 	}
+
+	switch pkgConfig.InitOpts.AgentImagePolicy {
+	case "", config.ZarfAgentImagePolicyEnforce, config.ZarfAgentImagePolicyAudit:
+	default:
+		return fmt.Errorf(lang.CmdInitErrValidateImagePolicy, pkgConfig.InitOpts.AgentImagePolicy)
+	}
 	return nil
 }
 
@@ -159,6 +165,7 @@ func init() {
 
 	v.SetDefault(V_INIT_COMPONENTS, "")
 	v.SetDefault(V_INIT_STORAGE_CLASS, "")
+	v.SetDefault(V_INIT_IMAGE_POLICY, "")
 
 	v.SetDefault(V_INIT_GIT_URL, "")
 	v.SetDefault(V_INIT_GIT_PUSH_USER, config.ZarfGitPushUser)
@@ -181,6 +188,11 @@ func init() {
 	initCmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdInitFlagConfirm)
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.Components, "components", v.GetString(V_INIT_COMPONENTS), lang.CmdInitFlagComponents)
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.StorageClass, "storage-class", v.GetString(V_INIT_STORAGE_CLASS), lang.CmdInitFlagStorageClass)
+	initCmd.Flags().StringVar(&pkgConfig.InitOpts.AgentImagePolicy, "agent-image-policy", v.GetString(V_INIT_IMAGE_POLICY), lang.CmdInitFlagImagePolicy)
+
+	// Flags for encrypting the sensitive fields of the Zarf state
+	initCmd.Flags().StringVar(&pkgConfig.InitOpts.StateEncryptionKeyPath, "state-encryption-key-file", v.GetString(V_INIT_STATE_KEY_FILE), lang.CmdInitFlagStateKeyFile)
+	initCmd.Flags().BoolVar(&pkgConfig.InitOpts.StateCRDs, "state-crds", v.GetBool(V_INIT_STATE_CRDS), lang.CmdInitFlagStateCRDs)
 
 	// Flags for using an external Git server
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Address, "git-url", v.GetString(V_INIT_GIT_URL), lang.CmdInitFlagGitURL)
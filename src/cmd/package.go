@@ -23,10 +23,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var insecureDeploy bool
-var shasum string
 var includeInspectSBOM bool
 var outputInspectSBOM string
+var rollbackGeneration int
 
 var packageCmd = &cobra.Command{
 	Use:     "package",
@@ -71,9 +70,11 @@ var packageCreateCmd = &cobra.Command{
 var packageDeployCmd = &cobra.Command{
 	Use:     "deploy [PACKAGE]",
 	Aliases: []string{"d"},
-	Short:   "Use to deploy a Zarf package from a local file or URL (runs offline)",
-	Long:    "Uses current kubecontext to deploy the packaged tarball onto a k8s cluster.",
-	Args:    cobra.MaximumNArgs(1),
+	Short:   "Use to deploy a Zarf package from a local file, URL or OCI registry (runs offline)",
+	Long: "Uses current kubecontext to deploy the packaged tarball onto a k8s cluster.\n" +
+		"Packages published with 'zarf package publish' can be deployed with an oci:// reference, e.g. oci://registry.example.com/packages/my-package:1.0.0-amd64, " +
+		"or from a local OCI image layout directory. Only the layers of the selected components and their images are pulled.",
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		pkgConfig.DeployOpts.PackagePath = choosePackage(args)
 
@@ -81,8 +82,19 @@ var packageDeployCmd = &cobra.Command{
 		pkgClient := packager.NewOrDie(&pkgConfig)
 		defer pkgClient.ClearTempPaths()
 
+		validateOutputFormat()
+
 		// Deploy the package
-		if err := pkgClient.Deploy(); err != nil {
+		err := pkgClient.Deploy()
+
+		// Print the report of failed deployments too, so pipelines can see which component failed
+		if outputFormat != "" {
+			if err := utils.PrintStructured(outputFormat, pkgClient.DeployReport()); err != nil {
+				message.Fatalf(err, "Unable to print the deployment report: %s", err.Error())
+			}
+		}
+
+		if err != nil {
 			message.Fatalf(err, "Failed to deploy package: %s", err.Error())
 		}
 	},
@@ -98,43 +110,89 @@ var packageInspectCmd = &cobra.Command{
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		pkgConfig.DeployOpts.PackagePath = choosePackage(args)
+		validateOutputFormat()
+
+		// Inspecting a signed package does not require its public key, the signature is only verified if one is provided
+		pkgConfig.DeployOpts.SkipSignatureValidation = true
 
 		// Configure the packager
 		pkgClient := packager.NewOrDie(&pkgConfig)
 		defer pkgClient.ClearTempPaths()
 
 		// Inspect the package
-		if err := pkgClient.Inspect(includeInspectSBOM, outputInspectSBOM); err != nil {
+		if err := pkgClient.Inspect(includeInspectSBOM, outputInspectSBOM, outputFormat); err != nil {
 			message.Fatalf(err, "Failed to inspect package: %s", err.Error())
 		}
 	},
 }
 
+var packagePublishCmd = &cobra.Command{
+	Use:   "publish [PACKAGE] [REPOSITORY]",
+	Short: "Publish a Zarf package to a remote OCI registry or a local OCI image layout",
+	Long: "Pushes a Zarf package to an OCI registry as a layered artifact, tagged with the package version and architecture.\n" +
+		"Each component and image is stored as its own layer so deployments only pull what they need.\n" +
+		"If the repository is not an oci:// URL it is treated as a directory to write a local OCI image layout into.\n" +
+		"Registries are accessed via credentials in your local '~/.docker/config.json'.",
+	Example: "  zarf package publish zarf-package-my-package-amd64-1.0.0.tar.zst oci://registry.example.com/packages\n" +
+		"  zarf package publish zarf-package-my-package-amd64-1.0.0.tar.zst ./my-package-layout",
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		pkgConfig.PublishOpts.PackagePath = args[0]
+		pkgConfig.PublishOpts.Repository = args[1]
+
+		// The signature is published along with the package and verified when it is deployed
+		pkgConfig.DeployOpts.SkipSignatureValidation = true
+
+		// Configure the packager
+		pkgClient := packager.NewOrDie(&pkgConfig)
+		defer pkgClient.ClearTempPaths()
+
+		// Publish the package
+		if err := pkgClient.Publish(); err != nil {
+			message.Fatalf(err, "Failed to publish package: %s", err.Error())
+		}
+	},
+}
+
 var packageListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"l"},
 	Short:   "List out all of the packages that have been deployed to the cluster",
 	Run: func(cmd *cobra.Command, args []string) {
+		validateOutputFormat()
+
 		// Get all the deployed packages
 		deployedZarfPackages, err := cluster.NewClusterOrDie().GetDeployedZarfPackages()
 		if err != nil {
 			message.Fatalf(err, "Unable to get the packages deployed to the cluster")
 		}
 
+		if outputFormat != "" {
+			if err := utils.PrintStructured(outputFormat, deployedZarfPackages); err != nil {
+				message.Fatalf(err, "Unable to print the deployed packages: %s", err.Error())
+			}
+			return
+		}
+
 		// Populate a pterm table of all the deployed packages
 		packageTable := pterm.TableData{
-			{"     Package ", "Components"},
+			{"     Package ", "Generation", "Components"},
 		}
 
 		for _, pkg := range deployedZarfPackages {
 			var components []string
 
 			for _, component := range pkg.DeployedComponents {
+				if component.Status == types.ComponentStatusFailed {
+					components = append(components, component.Name+" (failed)")
+					continue
+				}
 				components = append(components, component.Name)
 			}
 
 			packageTable = append(packageTable, pterm.TableData{{
 				fmt.Sprintf("     %s", pkg.Name),
+				fmt.Sprintf("%d", pkg.Generation),
 				fmt.Sprintf("%v", components),
 			}}...)
 		}
@@ -188,6 +246,37 @@ var packageRemoveCmd = &cobra.Command{
 	},
 }
 
+var packageRollbackCmd = &cobra.Command{
+	Use:   "rollback PACKAGE_NAME",
+	Args:  cobra.ExactArgs(1),
+	Short: "Use to roll back a deployed Zarf package to an earlier deployment of the package",
+	Long: "Use to roll back a deployed Zarf package to an earlier deployment of the package.\n" +
+		"Each helm chart of the package is rolled back to the revision recorded for that deployment and charts " +
+		"installed since are removed. Charts of that deployment that are no longer installed (i.e. removed with " +
+		"'zarf package remove --components') are skipped. Zarf keeps the last 10 deployments (generations) of each package. " +
+		"When the Zarf state is stored in custom resources (zarf init --state-crds) only the components, chart revisions " +
+		"and package version of earlier deployments are kept, so the rest of the package definition is the one of the latest deployment.",
+	Run: func(cmd *cobra.Command, args []string) {
+		// Configure the packager
+		pkgClient := packager.NewOrDie(&pkgConfig)
+		defer pkgClient.ClearTempPaths()
+
+		if err := pkgClient.Rollback(args[0], rollbackGeneration); err != nil {
+			message.Fatalf(err, "Unable to roll back the package with an error of: %#v", err)
+		}
+	},
+}
+
+// validateOutputFormat exits if --output was given a format that is not supported.
+func validateOutputFormat() {
+	if outputFormat == "" {
+		return
+	}
+	if err := utils.ValidateOutputFormat(outputFormat); err != nil {
+		message.Fatal(err, err.Error())
+	}
+}
+
 func choosePackage(args []string) string {
 	if len(args) > 0 {
 		return args[0]
@@ -220,13 +309,19 @@ func init() {
 	packageCmd.AddCommand(packageCreateCmd)
 	packageCmd.AddCommand(packageDeployCmd)
 	packageCmd.AddCommand(packageInspectCmd)
+	packageCmd.AddCommand(packagePublishCmd)
 	packageCmd.AddCommand(packageRemoveCmd)
+	packageCmd.AddCommand(packageRollbackCmd)
 	packageCmd.AddCommand(packageListCmd)
 
 	bindCreateFlags()
 	bindDeployFlags()
 	bindInspectFlags()
+	bindPublishFlags()
 	bindRemoveFlags()
+	bindRollbackFlags()
+
+	packageListCmd.Flags().StringVar(&outputFormat, "output", "", "Print the deployed packages to stdout as json or yaml")
 }
 
 func bindCreateFlags() {
@@ -242,6 +337,9 @@ func bindCreateFlags() {
 	v.SetDefault(V_PKG_CREATE_SKIP_SBOM, false)
 	v.SetDefault(V_PKG_CREATE_INSECURE, false)
 	v.SetDefault(V_PKG_CREATE_MAX_PACKAGE_SIZE, 0)
+	v.SetDefault(V_PKG_CREATE_SIGNING_KEY, "")
+	v.SetDefault(V_PKG_CREATE_SIGNING_KEY_PASS, "")
+	v.SetDefault(V_PKG_CREATE_DIFFERENTIAL, "")
 
 	createFlags.StringToStringVar(&pkgConfig.CreateOpts.SetVariables, "set", v.GetStringMapString(V_PKG_CREATE_SET), "Specify package variables to set on the command line (KEY=value)")
 	createFlags.StringVarP(&pkgConfig.CreateOpts.OutputDirectory, "output-directory", "o", v.GetString(V_PKG_CREATE_OUTPUT_DIR), "Specify the output directory for the created Zarf package")
@@ -250,6 +348,9 @@ func bindCreateFlags() {
 	createFlags.BoolVar(&pkgConfig.CreateOpts.SkipSBOM, "skip-sbom", v.GetBool(V_PKG_CREATE_SKIP_SBOM), "Skip generating SBOM for this package")
 	createFlags.BoolVar(&pkgConfig.CreateOpts.Insecure, "insecure", v.GetBool(V_PKG_CREATE_INSECURE), "Allow insecure registry connections when pulling OCI images")
 	createFlags.IntVarP(&pkgConfig.CreateOpts.MaxPackageSizeMB, "max-package-size", "m", v.GetInt(V_PKG_CREATE_MAX_PACKAGE_SIZE), "Specify the maximum size of the package in megabytes, packages larger than this will be split into multiple parts. Use 0 to disable splitting.")
+	createFlags.StringVar(&pkgConfig.CreateOpts.SigningKeyPath, "signing-key", v.GetString(V_PKG_CREATE_SIGNING_KEY), "Path to a private key file for signing the package with cosign")
+	createFlags.StringVar(&pkgConfig.CreateOpts.SigningKeyPassword, "signing-key-pass", v.GetString(V_PKG_CREATE_SIGNING_KEY_PASS), "Password to the private key file used for signing the package")
+	createFlags.StringVar(&pkgConfig.CreateOpts.DifferentialPackagePath, "differential", v.GetString(V_PKG_CREATE_DIFFERENTIAL), "Path to a previous version of the package (tarball, oci:// or OCI layout) to build a differential package against, omitting images and git repos that are unchanged")
 }
 
 func bindDeployFlags() {
@@ -261,25 +362,52 @@ func bindDeployFlags() {
 	v.SetDefault(V_PKG_DEPLOY_SET, map[string]string{})
 	v.SetDefault(V_PKG_DEPLOY_COMPONENTS, "")
 	v.SetDefault(V_PKG_DEPLOY_INSECURE, false)
+	v.SetDefault(V_PKG_DEPLOY_PLAIN_HTTP, false)
 	v.SetDefault(V_PKG_DEPLOY_SHASUM, "")
 	v.SetDefault(V_PKG_DEPLOY_SGET, "")
+	v.SetDefault(V_PKG_DEPLOY_PUBLIC_KEY, "")
+	v.SetDefault(V_PKG_DEPLOY_SKIP_SIG, false)
+	v.SetDefault(V_PKG_DEPLOY_ATOMIC, false)
 
 	deployFlags.StringToStringVar(&pkgConfig.DeployOpts.SetVariables, "set", v.GetStringMapString(V_PKG_DEPLOY_SET), "Specify deployment variables to set on the command line (KEY=value)")
 	deployFlags.StringVar(&pkgConfig.DeployOpts.Components, "components", v.GetString(V_PKG_DEPLOY_COMPONENTS), "Comma-separated list of components to install.  Adding this flag will skip the init prompts for which components to install")
-	deployFlags.BoolVar(&insecureDeploy, "insecure", v.GetBool(V_PKG_DEPLOY_INSECURE), "Skip shasum validation of remote package. Required if deploying a remote package and `--shasum` is not provided")
-	deployFlags.StringVar(&shasum, "shasum", v.GetString(V_PKG_DEPLOY_SHASUM), "Shasum of the package to deploy. Required if deploying a remote package and `--insecure` is not provided")
+	deployFlags.BoolVar(&pkgConfig.DeployOpts.Insecure, "insecure", v.GetBool(V_PKG_DEPLOY_INSECURE), "Skip shasum validation of remote package. Required if deploying a remote package and `--shasum` is not provided")
+	deployFlags.BoolVar(&pkgConfig.DeployOpts.PlainHTTP, "plain-http", v.GetBool(V_PKG_DEPLOY_PLAIN_HTTP), "Allow plain HTTP connections to the registry of oci:// packages")
+	deployFlags.StringVar(&pkgConfig.DeployOpts.Shasum, "shasum", v.GetString(V_PKG_DEPLOY_SHASUM), "Shasum of the package to deploy. Required if deploying a remote package and `--insecure` is not provided. For oci:// packages, the manifest digest to verify")
 	deployFlags.StringVar(&pkgConfig.DeployOpts.SGetKeyPath, "sget", v.GetString(V_PKG_DEPLOY_SGET), "Path to public sget key file for remote packages signed via cosign")
+	deployFlags.StringVarP(&pkgConfig.DeployOpts.PublicKeyPath, "key", "k", v.GetString(V_PKG_DEPLOY_PUBLIC_KEY), "Path to a public key file to verify the package signature and checksums with before deploying")
+	deployFlags.BoolVar(&pkgConfig.DeployOpts.SkipSignatureValidation, "skip-signature-validation", v.GetBool(V_PKG_DEPLOY_SKIP_SIG), "Deploy a signed package without verifying its signature. Required if deploying a signed package and `--key` is not provided")
+	deployFlags.StringVar(&outputFormat, "output", "", "Print a report of the deployment (components, charts, images and repos pushed, durations and connect strings) to stdout as json or yaml")
+	deployFlags.BoolVar(&pkgConfig.DeployOpts.Atomic, "atomic", v.GetBool(V_PKG_DEPLOY_ATOMIC), "If a component fails to deploy, uninstall or roll back the charts and manifests changed by this deployment (including the failed one) to the revision they had before it, in reverse order. Images, repos, files and data injections are not undone")
 }
 
 func bindInspectFlags() {
 	inspectFlags := packageInspectCmd.Flags()
 	inspectFlags.BoolVarP(&includeInspectSBOM, "sbom", "s", false, "View SBOM contents while inspecting the package")
 	inspectFlags.StringVar(&outputInspectSBOM, "sbom-out", "", "Specify an output directory for the SBOMs from the inspected Zarf package")
+	inspectFlags.StringVarP(&pkgConfig.DeployOpts.PublicKeyPath, "key", "k", "", "Path to a public key file to verify the package signature and checksums with")
+	inspectFlags.StringVar(&outputFormat, "output", "", "Print the package definition to stdout as json or yaml")
+}
+
+func bindPublishFlags() {
+	publishFlags := packagePublishCmd.Flags()
+
+	v.SetDefault(V_PKG_PUBLISH_INSECURE, false)
+
+	publishFlags.BoolVar(&pkgConfig.PublishOpts.Insecure, "insecure", v.GetBool(V_PKG_PUBLISH_INSECURE), "Allow plain HTTP connections to the registry")
 }
 
 func bindRemoveFlags() {
 	removeFlags := packageRemoveCmd.Flags()
 	removeFlags.BoolVar(&config.CommonOptions.Confirm, "confirm", false, "REQUIRED. Confirm the removal action to prevent accidental deletions")
 	removeFlags.StringVar(&pkgConfig.DeployOpts.Components, "components", v.GetString(V_PKG_DEPLOY_COMPONENTS), "Comma-separated list of components to uninstall")
+	removeFlags.BoolVar(&pkgConfig.RemoveOpts.Force, "force", v.GetBool(V_PKG_REMOVE_FORCE), "Remove the components even if other deployed components depend on them")
 	_ = packageRemoveCmd.MarkFlagRequired("confirm")
 }
+
+func bindRollbackFlags() {
+	rollbackFlags := packageRollbackCmd.Flags()
+	rollbackFlags.BoolVar(&config.CommonOptions.Confirm, "confirm", false, "REQUIRED. Confirm the rollback action to prevent accidental changes")
+	rollbackFlags.IntVar(&rollbackGeneration, "generation", 0, "The deployment generation to roll back to, defaults to the previous generation")
+	_ = packageRollbackCmd.MarkFlagRequired("confirm")
+}
@@ -23,6 +23,9 @@ var (
 	logLevel    string
 	arch        string
 
+	// Format of the machine-readable output for commands that support --output
+	outputFormat string
+
 	// Default global config for the CLI
 	pkgConfig = types.PackagerConfig{}
 
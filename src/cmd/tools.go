@@ -7,13 +7,16 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/anchore/syft/cmd/syft/cli"
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/config/lang"
 	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/internal/packager/git"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/pki"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	k9s "github.com/derailed/k9s/cmd"
 	craneCmd "github.com/google/go-containerregistry/cmd/crane/cmd"
 	"github.com/mholt/archiver/v3"
@@ -132,6 +135,123 @@ var generatePKICmd = &cobra.Command{
 	},
 }
 
+var rotateAgentTLSCmd = &cobra.Command{
+	Use:   "rotate-agent-tls",
+	Short: lang.CmdToolsRotateAgentTLSShort,
+	Long:  lang.CmdToolsRotateAgentTLSLong,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := cluster.NewClusterOrDie()
+		state, err := c.LoadZarfState()
+		if err != nil || state.Distro == "" {
+			// If no distro the zarf secret did not load properly
+			message.Fatalf(nil, lang.ErrLoadState)
+		}
+
+		spinner := message.NewProgressSpinner("Rotating the Zarf Agent certificate")
+		defer spinner.Stop()
+
+		if state, err = c.RotateAgentTLS(state); err != nil {
+			spinner.Fatalf(err, lang.CmdToolsRotateAgentTLSErr)
+		}
+
+		expiration, _ := pki.GetCertExpiration(state.AgentTLS.Cert)
+		spinner.Successf(lang.CmdToolsRotateAgentTLSSuccess, expiration.Format(time.RFC1123))
+	},
+}
+
+var rotateCredsCmd = &cobra.Command{
+	Use:       "rotate-credentials [registry|git|all]",
+	Aliases:   []string{"rotate-creds"},
+	Short:     lang.CmdToolsRotateCredsShort,
+	Long:      lang.CmdToolsRotateCredsLong,
+	ValidArgs: []string{"registry", "git", "all"},
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := "all"
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		c := cluster.NewClusterOrDie()
+		state, err := c.LoadZarfState()
+		if err != nil || state.Distro == "" {
+			// If no distro the zarf secret did not load properly
+			message.Fatalf(nil, lang.ErrLoadState)
+		}
+
+		if state.Distro == "YOLO" {
+			message.Fatal(nil, lang.CmdToolsRotateCredsErrYOLO)
+		}
+
+		var rotated bool
+
+		if target != "registry" {
+			if !state.GitServer.InternalServer {
+				message.Warnf(lang.CmdToolsRotateCredsExternal, "git server")
+			} else if !c.IsGitServerDeployed() {
+				message.Warn(lang.CmdToolsRotateCredsNoGitServer)
+			} else {
+				spinner := message.NewProgressSpinner(lang.CmdToolsRotateCredsGit)
+
+				// Gitea has to be updated with the old push password before the state forgets it
+				previousGitServer := state.GitServer
+				state.GitServer.PushPassword = utils.RandomString(config.ZarfGeneratedPasswordLen)
+				state.GitServer.PullPassword = utils.RandomString(config.ZarfGeneratedPasswordLen)
+
+				gitClient := git.New(state.GitServer)
+				if err := gitClient.UpdateZarfGiteaUsers(previousGitServer.PushPassword); err != nil {
+					spinner.Fatalf(err, lang.CmdToolsRotateCredsErrGit)
+				}
+
+				if err := c.SaveZarfState(state); err != nil {
+					// Gitea has to go back to the passwords in the state, or the push user would be locked out
+					if revertErr := gitClient.RevertZarfGiteaUsers(previousGitServer); revertErr != nil {
+						message.Warnf(lang.CmdToolsRotateCredsErrGitRevert, revertErr.Error(), state.GitServer.PushPassword)
+					}
+					spinner.Fatalf(err, lang.CmdToolsRotateCredsErrGit)
+				}
+
+				spinner.Successf(lang.CmdToolsRotateCredsGitDone)
+				rotated = true
+			}
+		}
+
+		if target != "git" {
+			if !state.RegistryInfo.InternalRegistry {
+				message.Warnf(lang.CmdToolsRotateCredsExternal, "registry")
+			} else {
+				spinner := message.NewProgressSpinner(lang.CmdToolsRotateCredsRegistry)
+
+				// The htpasswd is generated from the state, so a failed rollout can be fixed by running this again
+				state.RegistryInfo.PushPassword = utils.RandomString(config.ZarfGeneratedPasswordLen)
+				state.RegistryInfo.PullPassword = utils.RandomString(config.ZarfGeneratedPasswordLen)
+
+				if err := c.SaveZarfState(state); err != nil {
+					spinner.Fatalf(err, lang.CmdToolsRotateCredsErrRegistry)
+				}
+
+				if err := c.UpdateRegistryHtpasswd(state.RegistryInfo); err != nil {
+					spinner.Fatalf(err, lang.CmdToolsRotateCredsErrRegistry)
+				}
+
+				spinner.Successf(lang.CmdToolsRotateCredsRegistryDone)
+				rotated = true
+			}
+		}
+
+		if !rotated {
+			return
+		}
+
+		spinner := message.NewProgressSpinner(lang.CmdToolsRotateCredsSecrets)
+		if err := c.UpdateZarfManagedSecrets(state); err != nil {
+			spinner.Fatalf(err, lang.CmdToolsRotateCredsErrSecrets)
+		}
+		spinner.Successf(lang.CmdToolsRotateCredsSuccess)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(toolsCmd)
 	toolsCmd.AddCommand(archiverCmd)
@@ -145,6 +265,9 @@ func init() {
 	toolsCmd.AddCommand(generatePKICmd)
 	generatePKICmd.Flags().StringArrayVar(&subAltNames, "sub-alt-name", []string{}, lang.CmdToolsGenPkiFlagAltName)
 
+	toolsCmd.AddCommand(rotateAgentTLSCmd)
+	toolsCmd.AddCommand(rotateCredsCmd)
+
 	archiverCmd.AddCommand(archiverCompressCmd)
 	archiverCmd.AddCommand(archiverDecompressCmd)
 
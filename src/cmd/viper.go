@@ -24,6 +24,11 @@ const (
 	// Init config keys
 	V_INIT_COMPONENTS    = "init.components"
 	V_INIT_STORAGE_CLASS = "init.storage_class"
+	V_INIT_IMAGE_POLICY  = "init.agent_image_policy"
+
+	// Init state encryption config keys
+	V_INIT_STATE_KEY_FILE = "init.state_encryption.key_file"
+	V_INIT_STATE_CRDS     = "init.state_crds"
 
 	// Init Git config keys
 	V_INIT_GIT_URL       = "init.git.url"
@@ -49,13 +54,26 @@ const (
 	V_PKG_CREATE_SKIP_SBOM        = "package.create.skip_sbom"
 	V_PKG_CREATE_INSECURE         = "package.create.insecure"
 	V_PKG_CREATE_MAX_PACKAGE_SIZE = "package.create.max_package_size"
+	V_PKG_CREATE_SIGNING_KEY      = "package.create.signing_key"
+	V_PKG_CREATE_SIGNING_KEY_PASS = "package.create.signing_key_password"
+	V_PKG_CREATE_DIFFERENTIAL     = "package.create.differential"
 
 	// Package deploy config keys
 	V_PKG_DEPLOY_SET        = "package.deploy.set"
 	V_PKG_DEPLOY_COMPONENTS = "package.deploy.components"
 	V_PKG_DEPLOY_INSECURE   = "package.deploy.insecure"
+	V_PKG_DEPLOY_PLAIN_HTTP = "package.deploy.plain_http"
 	V_PKG_DEPLOY_SHASUM     = "package.deploy.shasum"
 	V_PKG_DEPLOY_SGET       = "package.deploy.sget"
+	V_PKG_DEPLOY_PUBLIC_KEY = "package.deploy.public_key"
+	V_PKG_DEPLOY_SKIP_SIG   = "package.deploy.skip_signature_validation"
+	V_PKG_DEPLOY_ATOMIC     = "package.deploy.atomic"
+
+	// Package publish config keys
+	V_PKG_PUBLISH_INSECURE = "package.publish.insecure"
+
+	// Package remove config keys
+	V_PKG_REMOVE_FORCE = "package.remove.force"
 )
 
 func initViper() {
@@ -38,6 +38,14 @@ const (
 
 	ZarfAgentHost = "agent-hook.zarf.svc"
 
+	// Modes of the Zarf Agent image policy, images not delivered by a Zarf package are rejected or only reported
+	ZarfAgentImagePolicyEnforce = "enforce"
+	ZarfAgentImagePolicyAudit   = "audit"
+
+	// Providers of the key that encrypts the sensitive fields of the Zarf state at rest
+	ZarfStateEncryptionLocal  = "local"
+	ZarfStateEncryptionKeyEnv = "ZARF_STATE_ENCRYPTION_KEY"
+
 	ZarfConnectLabelName             = "zarf.dev/connect-name"
 	ZarfConnectAnnotationDescription = "zarf.dev/connect-description"
 	ZarfConnectAnnotationURL         = "zarf.dev/connect-url"
@@ -49,10 +57,13 @@ const (
 	ZarfGitCacheDir   = "repos"
 
 	ZarfYAML          = "zarf.yaml"
+	ZarfYAMLSignature = "zarf.yaml.sig"
+	ZarfChecksumsTxt  = "checksums.txt"
 	ZarfSBOMDir       = "zarf-sbom"
 	ZarfPackagePrefix = "zarf-package-"
 
-	ZarfInClusterContainerRegistryURL      = "http://zarf-registry-http.zarf.svc.cluster.local:5000"
+	// ZarfInClusterContainerRegistryURL is the service of the zarf-docker-registry release in packages/zarf-registry
+	ZarfInClusterContainerRegistryURL      = "http://zarf-docker-registry.zarf.svc.cluster.local:5000"
 	ZarfInClusterContainerRegistryNodePort = 31999
 
 	ZarfInClusterGitServiceURL = "http://zarf-gitea-http.zarf.svc.cluster.local:3000"
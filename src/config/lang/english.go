@@ -57,7 +57,9 @@ const (
 		"to whatever resource you are trying to connect to."
 
 	// zarf connect list
-	CmdConnectListShort = "List all available connection shortcuts."
+	CmdConnectListShort      = "List all available connection shortcuts."
+	CmdConnectListFlagOutput = "Print the connection shortcuts to stdout as json or yaml"
+	CmdConnectListErrGet     = "Unable to get the connection shortcuts from the cluster"
 
 	CmdConnectFlagName       = "Specify the resource name.  E.g. name=unicorns or name=unicorn-pod-7448499f4d-b5bk6"
 	CmdConnectFlagNamespace  = "Specify the namespace.  E.g. namespace=default"
@@ -84,6 +86,7 @@ const (
 
 	CmdDestroyErrNoScriptPath           = "Unable to find the folder (%s) which has the scripts to cleanup the cluster. Please double-check you have the right kube-context"
 	CmdDestroyErrScriptPermissionDenied = "Received 'permission denied' when trying to execute the script (%s). Please double-check you have the correct kube-context."
+	CmdDestroyErrCRDs                   = "Unable to remove the Zarf custom resource definitions"
 
 	// zarf init
 	CmdInitShort = "Prepares a k8s cluster for the deployment of Zarf packages"
@@ -107,10 +110,11 @@ const (
 		"# Initializing w/ an external registry:\nzarf init --registry-push-password={PASSWORD} --registry-push-username={USERNAME} --registry-url={URL}\n\n" +
 		"# Initializing w/ an external git server:\nzarf init --git-push-password={PASSWORD} --git-push-username={USERNAME} --git-url={URL}\n\n"
 
-	CmdInitErrFlags            = "Invalid command flags were provided."
-	CmdInitErrDownload         = "failed to download the init package: %s"
-	CmdInitErrValidateGit      = "the 'git-push-username' and 'git-push-password' flags must be provided if the 'git-url' flag is provided"
-	CmdInitErrValidateRegistry = "the 'registry-push-username' and 'registry-push-password' flags must be provided if the 'registry-url' flag is provided "
+	CmdInitErrFlags               = "Invalid command flags were provided."
+	CmdInitErrDownload            = "failed to download the init package: %s"
+	CmdInitErrValidateGit         = "the 'git-push-username' and 'git-push-password' flags must be provided if the 'git-url' flag is provided"
+	CmdInitErrValidateRegistry    = "the 'registry-push-username' and 'registry-push-password' flags must be provided if the 'registry-url' flag is provided "
+	CmdInitErrValidateImagePolicy = "the 'agent-image-policy' flag must be 'enforce' or 'audit', got '%s'"
 
 	CmdInitDownloadAsk       = "It seems the init package could not be found locally, but can be downloaded from %s"
 	CmdInitDownloadNote      = "Note: This will require an internet connection."
@@ -121,6 +125,9 @@ const (
 	CmdInitFlagConfirm      = "Confirm the install without prompting"
 	CmdInitFlagComponents   = "Specify which optional components to install.  E.g. --components=git-server,logging"
 	CmdInitFlagStorageClass = "Specify the storage class to use for the registry.  E.g. --storage-class=standard"
+	CmdInitFlagImagePolicy  = "Have the Zarf Agent reject ('enforce') or only report ('audit') pods whose images were not delivered by a deployed Zarf package"
+	CmdInitFlagStateKeyFile = "Path to a 32 byte (raw or base64) key used to encrypt the credentials in the Zarf state. The key can also be set with ZARF_STATE_ENCRYPTION_KEY"
+	CmdInitFlagStateCRDs    = "Install the ZarfState and ZarfDeployedPackage CRDs and store the Zarf state and deployed packages in them, keeping only credentials in secrets"
 
 	CmdInitFlagGitURL      = "External git server url to use for this Zarf cluster"
 	CmdInitFlagGitPushUser = "Username to access to the git server Zarf is configured to use. User must be able to create repositories via 'git push'"
@@ -162,6 +169,27 @@ const (
 	CmdToolsGenPkiSuccess     = "Successfully created a chain of trust for %s"
 	CmdToolsGenPkiFlagAltName = "Specify Subject Alternative Names for the certificate"
 
+	CmdToolsRotateAgentTLSShort   = "Generates a new certificate for the Zarf Agent and rolls it out to the cluster"
+	CmdToolsRotateAgentTLSLong    = "Generates a new CA and certificate for the Zarf Agent, updates the agent TLS secret and the webhook CA bundle, restarts the agent and then saves them to the zarf-state secret. If the rollout fails the agent is put back on its previous certificate. 'zarf init' and 'zarf package deploy' also do this automatically when the certificate expires within 30 days."
+	CmdToolsRotateAgentTLSErr     = "Unable to rotate the Zarf Agent certificate"
+	CmdToolsRotateAgentTLSSuccess = "Rotated the Zarf Agent certificate, it is valid until %s"
+
+	CmdToolsRotateCredsShort        = "Generates new credentials for the Zarf registry and git server and rolls them out to the cluster"
+	CmdToolsRotateCredsLong         = "Generates new push and pull passwords for the Zarf registry and/or git server, updates the registry htpasswd and the Gitea users, saves them to the zarf-state secret and refreshes the private-registry and private-git-server secrets in every Zarf-managed namespace. Defaults to rotating both."
+	CmdToolsRotateCredsRegistry     = "Rotating the Zarf registry credentials"
+	CmdToolsRotateCredsGit          = "Rotating the Zarf git server credentials"
+	CmdToolsRotateCredsSecrets      = "Refreshing the Zarf registry and git server secrets in Zarf-managed namespaces"
+	CmdToolsRotateCredsExternal     = "The %s is not managed by Zarf, its credentials must be rotated outside of Zarf"
+	CmdToolsRotateCredsNoGitServer  = "The Zarf git server is not deployed, skipping its credentials"
+	CmdToolsRotateCredsErrYOLO      = "This cluster was initialized in YOLO mode and has no Zarf registry or git server credentials to rotate"
+	CmdToolsRotateCredsErrRegistry  = "Unable to rotate the Zarf registry credentials"
+	CmdToolsRotateCredsErrGit       = "Unable to rotate the Zarf git server credentials"
+	CmdToolsRotateCredsErrGitRevert = "Unable to change the git server passwords back (%s), the password of the git push user is now %s"
+	CmdToolsRotateCredsErrSecrets   = "Unable to refresh the Zarf registry and git server secrets"
+	CmdToolsRotateCredsSuccess      = "Rotated the Zarf credentials, use 'zarf tools get-git-password' to see the new git server password"
+	CmdToolsRotateCredsRegistryDone = "Rotated the Zarf registry credentials"
+	CmdToolsRotateCredsGitDone      = "Rotated the Zarf git server credentials"
+
 	CmdToolsSbomShort = "Generates a Software Bill of Materials (SBOM) for the given package"
 	CmdToolsSbomErr   = "Unable to create sbom (syft) CLI"
 
@@ -180,12 +208,17 @@ const (
 	AgentInfoWebhookAllowed = "Webhook [%s - %s] - Allowed: %t"
 	AgentInfoShutdown       = "Shutdown gracefully..."
 	AgentInfoPort           = "Server running in port: %s"
+	AgentInfoStateLoaded    = "Loaded the Zarf state from the cluster"
 
 	AgentErrBadRequest             = "could not read request body: %s"
 	AgentErrBindHandler            = "Unable to bind the webhook handler"
 	AgentErrCouldNotDeserializeReq = "could not deserialize request: %s"
-	AgentErrGetState               = "failed to load zarf state from file: %w"
+	AgentErrConnectCluster         = "unable to connect to the Kubernetes cluster"
+	AgentErrGetState               = "failed to load zarf state: %w"
 	AgentErrHostnameMatch          = "failed to complete hostname matching: %w"
+	AgentErrImagePolicy            = "image %s was not delivered by a deployed Zarf package"
+	AgentErrImagePolicyLoad        = "unable to load the images of the deployed Zarf packages: %w"
+	AgentErrImagePolicyEvent       = "Unable to record the image policy event: %s"
 	AgentErrImageSwap              = "Unable to swap the host for (%s)"
 	AgentErrInvalidMethod          = "invalid method only POST requests are allowed"
 	AgentErrInvalidOp              = "invalid operation: %s"
@@ -193,8 +226,11 @@ const (
 	AgentErrMarshallJSONPatch      = "unable to marshall the json patch"
 	AgentErrMarshalResponse        = "unable to marshal the response"
 	AgentErrNilReq                 = "malformed admission review: request is nil"
+	AgentErrParseState             = "Unable to parse the Zarf state: %s"
 	AgentErrShutdown               = "unable to properly shutdown the web server"
+	AgentErrUnsupportedKind        = "unsupported workload kind: %s"
 	AgentErrStart                  = "Failed to start the web server"
+	AgentErrStateNotLoaded         = "the zarf state has not been loaded from the cluster yet"
 )
 
 // src/internal/packager/validate.
@@ -207,10 +243,14 @@ const (
 	PkgValidateErrChartVersion            = "chart %s must include a chart version"
 	PkgValidateErrComponentNameNotUnique  = "component name '%s' is not unique"
 	PkgValidateErrComponent               = "invalid component: %w"
+	PkgValidateErrComponentDistro         = "component %s has an invalid distro %q, it must match one of %s (optionally prefixed with ! to exclude it)"
 	PkgValidateErrComponentReqDefault     = "component %s cannot be both required and default"
 	PkgValidateErrComponentReqGrouped     = "component %s cannot be both required and grouped"
 	PkgValidateErrComponentYOLO           = "component %s incompatible with the online-only package flag (metadata.yolo): %w"
 	PkgValidateErrConstant                = "invalid package constant: %w"
+	PkgValidateErrDependencyCycle         = "component dependencies cannot be circular: %s"
+	PkgValidateErrDependencyGrouped       = "component %s cannot depend on %s because it is part of a choice group"
+	PkgValidateErrDependencyMissing       = "component %s depends on %s which is not a component of this package"
 	PkgValidateErrImportPathInvalid       = "invalid file path \"%s\" provided directory must contain a valid zarf.yaml file"
 	PkgValidateErrImportPathMissing       = "imported package %s must include a path"
 	PkgValidateErrInitNoYOLO              = "sorry, you can't YOLO an init package"
@@ -222,8 +262,19 @@ const (
 	PkgValidateErrPkgConstantName         = "constant name '%s' must be all uppercase and contain no special characters except _"
 	PkgValidateErrPkgName                 = "package name '%s' must be all lowercase and contain no special characters except -"
 	PkgValidateErrPkgVariableName         = "variable name '%s' must be all uppercase and contain no special characters except _"
+	PkgValidateErrRequirement             = "invalid package requirement: %w"
+	PkgValidateErrRequirementSelf         = "package %s cannot require itself"
+	PkgValidateErrRequirementVersion      = "version constraint '%s' of the required package %s is invalid: %w"
 	PkgValidateErrVariable                = "invalid package variable: %w"
+	PkgValidateErrWait                    = "invalid wait condition: %w"
+	PkgValidateErrWaitCondition           = "wait condition for %s can never be met, it does not have a %s condition (valid conditions are %v)"
+	PkgValidateErrWaitJSONPath            = "wait condition for %s has an invalid jsonPath '%s': %w"
+	PkgValidateErrWaitJSONPathValue       = "wait condition for %s must have both a jsonPath and a value or neither"
+	PkgValidateErrWaitKindMissing         = "wait conditions must include a kind"
+	PkgValidateErrWaitNameOrSelector      = "wait condition for %s must only have a name or selector"
+	PkgValidateErrWaitSelector            = "wait condition for %s has an invalid selector '%s': %w"
 	PkgValidateErrYOLONoArch              = "cluster architecture not allowed"
+	PkgValidateErrYOLONoChart             = "pushing charts to the registry not allowed"
 	PkgValidateErrYOLONoDistro            = "cluster distros not allowed"
 	PkgValidateErrYOLONoGit               = "git repos not allowed"
 	PkgValidateErrYOLONoOCI               = "OCI images not allowed"
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/agent/operations"
+	"github.com/defenseunicorns/zarf/src/internal/packager/git"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ArgoSource contains the repository of an Argo CD Application source, sources with a chart point at helm repositories.
+type ArgoSource struct {
+	RepoURL string `json:"repoURL"`
+	Chart   string `json:"chart,omitempty"`
+}
+
+// ArgoApplicationSpec contains the sources of an Argo CD Application.
+type ArgoApplicationSpec struct {
+	Source  *ArgoSource  `json:"source,omitempty"`
+	Sources []ArgoSource `json:"sources,omitempty"`
+}
+
+// ArgoApplication contains the spec of an Argo CD Application.
+type ArgoApplication struct {
+	Spec ArgoApplicationSpec `json:"spec"`
+}
+
+// ArgoGitGenerator contains the repository of an Argo CD ApplicationSet git generator.
+type ArgoGitGenerator struct {
+	RepoURL string `json:"repoURL"`
+}
+
+// ArgoApplicationSet contains the git generators and the Application template of an Argo CD ApplicationSet.
+type ArgoApplicationSet struct {
+	Spec struct {
+		Generators []struct {
+			Git *ArgoGitGenerator `json:"git,omitempty"`
+		} `json:"generators"`
+		Template struct {
+			Spec ArgoApplicationSpec `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+}
+
+// NewArgoApplicationMutationHook creates a new instance of the Argo CD Application and ApplicationSet mutation hook.
+func NewArgoApplicationMutationHook() operations.Hook {
+	message.Debug("hooks.NewArgoApplicationMutationHook()")
+	return operations.Hook{
+		Create: mutateArgoApplication,
+		Update: mutateArgoApplication,
+	}
+}
+
+// NewArgoRepositoryMutationHook creates a new instance of the Argo CD repository secret mutation hook.
+func NewArgoRepositoryMutationHook() operations.Hook {
+	message.Debug("hooks.NewArgoRepositoryMutationHook()")
+	return operations.Hook{
+		Create: mutateArgoRepository,
+		Update: mutateArgoRepository,
+	}
+}
+
+// mutateArgoApplication mutates the git repository urls of Applications and ApplicationSets to point to the
+// repository URL defined in the ZarfState.
+func mutateArgoApplication(r *v1.AdmissionRequest) (*operations.Result, error) {
+	message.Debugf("hooks.mutateArgoApplication()(*v1.AdmissionRequest) - %#v , %s/%s: %#v", r.Kind, r.Namespace, r.Name, r.Operation)
+
+	state, err := getZarfState()
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrGetState, err)
+	}
+
+	var patches []operations.PatchOperation
+
+	if r.Kind.Kind == "ApplicationSet" {
+		src := &ArgoApplicationSet{}
+		if err := json.Unmarshal(r.Object.Raw, &src); err != nil {
+			return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+		}
+
+		for idx, generator := range src.Spec.Generators {
+			if generator.Git == nil {
+				continue
+			}
+			if patchedURL, ok := mutateArgoRepoURL(state.GitServer, generator.Git.RepoURL); ok {
+				patches = append(patches, operations.ReplacePatchOperation(fmt.Sprintf("/spec/generators/%d/git/repoURL", idx), patchedURL))
+			}
+		}
+
+		patches = append(patches, populateArgoSourcePatches(state.GitServer, "/spec/template/spec", src.Spec.Template.Spec)...)
+	} else {
+		src := &ArgoApplication{}
+		if err := json.Unmarshal(r.Object.Raw, &src); err != nil {
+			return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+		}
+
+		patches = populateArgoSourcePatches(state.GitServer, "/spec", src.Spec)
+	}
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patches,
+	}, nil
+}
+
+// mutateArgoRepository mutates the url of an Argo CD repository secret to point to the repository URL defined in the
+// ZarfState and swaps its credentials for the Zarf git server pull credentials.
+func mutateArgoRepository(r *v1.AdmissionRequest) (*operations.Result, error) {
+	message.Debugf("hooks.mutateArgoRepository()(*v1.AdmissionRequest) - %#v , %s/%s: %#v", r.Kind, r.Namespace, r.Name, r.Operation)
+
+	state, err := getZarfState()
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrGetState, err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := json.Unmarshal(r.Object.Raw, &secret); err != nil {
+		return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+	}
+
+	var patches []operations.PatchOperation
+
+	// Like Application sources with a chart, helm (and OCI) repositories are not on the Zarf git server
+	if !isArgoGitRepository(secret) {
+		message.Debugf("Skipping the Argo CD repository secret %s/%s as it is not a git repository", r.Namespace, r.Name)
+		return &operations.Result{Allowed: true}, nil
+	}
+
+	// Secret data is base64 encoded in the patch like in the secret itself
+	if patchedURL, ok := mutateArgoRepoURL(state.GitServer, string(secret.Data["url"])); ok {
+		encode := base64.StdEncoding.EncodeToString
+		patches = append(patches,
+			operations.ReplacePatchOperation("/data/url", encode([]byte(patchedURL))),
+			operations.AddPatchOperation("/data/username", encode([]byte(state.GitServer.PullUsername))),
+			operations.AddPatchOperation("/data/password", encode([]byte(state.GitServer.PullPassword))),
+		)
+	}
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patches,
+	}, nil
+}
+
+// isArgoGitRepository returns whether an Argo CD repository secret is for a git repository, which is the default when
+// the secret does not set a type.
+func isArgoGitRepository(secret *corev1.Secret) bool {
+	repoType, ok := secret.StringData["type"]
+	if !ok {
+		repoType = string(secret.Data["type"])
+	}
+
+	return repoType == "" || strings.EqualFold(repoType, "git")
+}
+
+// populateArgoSourcePatches returns the patches for the git sources of an Application spec at the given path.
+func populateArgoSourcePatches(gitServer types.GitServerInfo, specPath string, spec ArgoApplicationSpec) []operations.PatchOperation {
+	var patches []operations.PatchOperation
+
+	if spec.Source != nil && spec.Source.Chart == "" {
+		if patchedURL, ok := mutateArgoRepoURL(gitServer, spec.Source.RepoURL); ok {
+			patches = append(patches, operations.ReplacePatchOperation(specPath+"/source/repoURL", patchedURL))
+		}
+	}
+
+	for idx, source := range spec.Sources {
+		if source.Chart != "" {
+			continue
+		}
+		if patchedURL, ok := mutateArgoRepoURL(gitServer, source.RepoURL); ok {
+			patches = append(patches, operations.ReplacePatchOperation(fmt.Sprintf("%s/sources/%d/repoURL", specPath, idx), patchedURL))
+		}
+	}
+
+	return patches
+}
+
+// mutateArgoRepoURL returns the url of the repo on the Zarf git server and true if the url needs to be mutated.
+// NOTE: Like the Flux hook we do not mutate urls that already point at the Zarf git server, and urls that are not
+// git urls (e.g. ApplicationSet template parameters) are left as they are.
+func mutateArgoRepoURL(gitServer types.GitServerInfo, repoURL string) (string, bool) {
+	if repoURL == "" {
+		return "", false
+	}
+
+	isPatched, err := utils.DoHostnamesMatch(gitServer.Address, repoURL)
+	if err != nil || isPatched {
+		return "", false
+	}
+
+	patchedURL, err := git.New(gitServer).TransformURL(repoURL)
+	if err != nil {
+		message.Warnf("Unable to transform the git url, using the original url we have: %s", repoURL)
+		return "", false
+	}
+
+	message.Debugf("original git URL of (%s) got mutated to (%s)", repoURL, patchedURL)
+	return patchedURL, true
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/internal/agent/operations"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var argoTestGitServer = types.GitServerInfo{
+	Address:      "http://zarf-gitea-http.zarf.svc.cluster.local:3000",
+	PushUsername: "zarf-git-user",
+}
+
+func TestMutateArgoRepoURL(t *testing.T) {
+	tests := []struct {
+		repoURL     string
+		wantURL     string
+		wantMutated bool
+	}{
+		{
+			repoURL:     "https://github.com/stefanprodan/podinfo.git",
+			wantURL:     "http://zarf-gitea-http.zarf.svc.cluster.local:3000/zarf-git-user/podinfo-1646971829",
+			wantMutated: true,
+		},
+		{
+			repoURL: "http://zarf-gitea-http.zarf.svc.cluster.local:3000/zarf-git-user/podinfo-1646971829",
+		},
+		{
+			repoURL: "{{url}}",
+		},
+		{
+			repoURL: "",
+		},
+	}
+
+	for _, tt := range tests {
+		patchedURL, mutated := mutateArgoRepoURL(argoTestGitServer, tt.repoURL)
+		require.Equal(t, tt.wantMutated, mutated, tt.repoURL)
+		require.Equal(t, tt.wantURL, patchedURL, tt.repoURL)
+	}
+}
+
+func TestIsArgoGitRepository(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret corev1.Secret
+		want   bool
+	}{
+		{name: "no type", secret: corev1.Secret{}, want: true},
+		{name: "git data", secret: corev1.Secret{Data: map[string][]byte{"type": []byte("git")}}, want: true},
+		{name: "git string data", secret: corev1.Secret{StringData: map[string]string{"type": "git"}}, want: true},
+		{name: "helm data", secret: corev1.Secret{Data: map[string][]byte{"type": []byte("helm")}}, want: false},
+		{name: "helm oci", secret: corev1.Secret{Data: map[string][]byte{"type": []byte("helm"), "enableOCI": []byte("true")}}, want: false},
+		{name: "helm string data", secret: corev1.Secret{StringData: map[string]string{"type": "helm"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, isArgoGitRepository(&tt.secret), tt.name)
+	}
+}
+
+func TestPopulateArgoSourcePatches(t *testing.T) {
+	spec := ArgoApplicationSpec{
+		Source: &ArgoSource{RepoURL: "https://github.com/stefanprodan/podinfo.git"},
+		Sources: []ArgoSource{
+			{RepoURL: "https://stefanprodan.github.io/podinfo", Chart: "podinfo"},
+			{RepoURL: "https://github.com/stefanprodan/podinfo.git"},
+		},
+	}
+
+	patches := populateArgoSourcePatches(argoTestGitServer, "/spec", spec)
+	require.Equal(t, []operations.PatchOperation{
+		operations.ReplacePatchOperation("/spec/source/repoURL", "http://zarf-gitea-http.zarf.svc.cluster.local:3000/zarf-git-user/podinfo-1646971829"),
+		operations.ReplacePatchOperation("/spec/sources/1/repoURL", "http://zarf-gitea-http.zarf.svc.cluster.local:3000/zarf-git-user/podinfo-1646971829"),
+	}, patches)
+}
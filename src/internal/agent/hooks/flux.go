@@ -7,19 +7,20 @@ package hooks
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/config/lang"
 	"github.com/defenseunicorns/zarf/src/internal/agent/operations"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
 	"github.com/defenseunicorns/zarf/src/internal/packager/git"
+	"github.com/defenseunicorns/zarf/src/internal/packager/oci"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
 	v1 "k8s.io/api/admission/v1"
 )
 
-const zarfStatePath = "/etc/zarf-state/state"
-
 // SecretRef contains the name used to reference a git repository secret.
 type SecretRef struct {
 	Name string `json:"name"`
@@ -55,7 +56,7 @@ func mutateGitRepo(r *v1.AdmissionRequest) (result *operations.Result, err error
 	)
 
 	// Form the state.GitServer.Address from the state
-	if state, err = getStateFromAgentPod(zarfStatePath); err != nil {
+	if state, err = getZarfState(); err != nil {
 		return nil, fmt.Errorf(lang.AgentErrGetState, err)
 	}
 
@@ -109,3 +110,98 @@ func populatePatchOperations(repoURL string, secretName string) []operations.Pat
 
 	return patches
 }
+
+// GenericOCIRepo contains the URL of a Flux HelmRepository or OCIRepository and the secret that corresponds to it.
+type GenericOCIRepo struct {
+	Spec struct {
+		URL       string    `json:"url"`
+		SecretRef SecretRef `json:"secretRef,omitempty"`
+	}
+}
+
+// NewHelmRepositoryMutationHook creates a new instance of the Flux HelmRepository mutation hook.
+func NewHelmRepositoryMutationHook() operations.Hook {
+	message.Debug("hooks.NewHelmRepositoryMutationHook()")
+	return operations.Hook{
+		Create: mutateHelmRepo,
+		Update: mutateHelmRepo,
+	}
+}
+
+// NewOCIRepositoryMutationHook creates a new instance of the Flux OCIRepository mutation hook.
+func NewOCIRepositoryMutationHook() operations.Hook {
+	message.Debug("hooks.NewOCIRepositoryMutationHook()")
+	return operations.Hook{
+		Create: mutateOCIRepo,
+		Update: mutateOCIRepo,
+	}
+}
+
+// mutateHelmRepo mutates the url of OCI helm repositories to point to the registry defined in the ZarfState.
+// NOTE: Flux appends the chart name to the repository url, so the path is kept as is without a checksum.
+func mutateHelmRepo(r *v1.AdmissionRequest) (*operations.Result, error) {
+	return mutateOCISource(r, utils.SwapHostWithoutChecksum)
+}
+
+// mutateOCIRepo mutates the url of OCI repositories to point to the registry defined in the ZarfState, using the same
+// repository names as images pushed by Zarf.
+func mutateOCIRepo(r *v1.AdmissionRequest) (*operations.Result, error) {
+	return mutateOCISource(r, utils.SwapHost)
+}
+
+// mutateOCISource patches the url of a Flux source with swapHost and references the Zarf registry pull secret.
+func mutateOCISource(r *v1.AdmissionRequest, swapHost func(src string, targetHost string) (string, error)) (*operations.Result, error) {
+	message.Debugf("hooks.mutateOCISource()(*v1.AdmissionRequest) - %#v , %s/%s: %#v", r.Kind, r.Namespace, r.Name, r.Operation)
+
+	state, err := getZarfState()
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrGetState, err)
+	}
+
+	src := &GenericOCIRepo{}
+	if err = json.Unmarshal(r.Object.Raw, &src); err != nil {
+		return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+	}
+
+	allowed := &operations.Result{Allowed: true}
+
+	// Classic helm repositories are served over HTTP(S) and can't be mirrored into the registry
+	if !strings.HasPrefix(src.Spec.URL, oci.URLPrefix) {
+		message.Debugf("Not mutating the non-OCI url (%s)", src.Spec.URL)
+		return allowed, nil
+	}
+
+	registry, insecure := cluster.InClusterRegistryAddress(state.RegistryInfo)
+
+	// Don't mutate a URL that has already been mutated
+	isPatched, err := utils.DoHostnamesMatch(oci.URLPrefix+registry, src.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrHostnameMatch, err)
+	}
+	if isPatched {
+		return allowed, nil
+	}
+
+	patchedURL, err := swapHost(strings.TrimPrefix(src.Spec.URL, oci.URLPrefix), registry)
+	if err != nil {
+		message.Warnf(lang.AgentErrImageSwap, src.Spec.URL)
+		return allowed, nil
+	}
+	patchedURL = oci.URLPrefix + patchedURL
+	message.Debugf("original OCI URL of (%s) got mutated to (%s)", src.Spec.URL, patchedURL)
+
+	allowed.PatchOps = append(allowed.PatchOps, operations.ReplacePatchOperation("/spec/url", patchedURL))
+
+	// If a prior secret exists, replace it
+	if src.Spec.SecretRef.Name != "" {
+		allowed.PatchOps = append(allowed.PatchOps, operations.ReplacePatchOperation("/spec/secretRef/name", config.ZarfImagePullSecretName))
+	} else {
+		allowed.PatchOps = append(allowed.PatchOps, operations.AddPatchOperation("/spec/secretRef", SecretRef{Name: config.ZarfImagePullSecretName}))
+	}
+
+	if insecure {
+		allowed.PatchOps = append(allowed.PatchOps, operations.AddPatchOperation("/spec/insecure", true))
+	}
+
+	return allowed, nil
+}
@@ -7,7 +7,6 @@ package hooks
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/config/lang"
@@ -48,76 +47,112 @@ func mutatePod(r *v1.AdmissionRequest) (*operations.Result, error) {
 		return &operations.Result{Msg: err.Error()}, nil
 	}
 
+	zarfState, err := getZarfState()
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrGetState, err)
+	}
+	containerRegistryURL := config.GetRegistry(zarfState)
+
 	if pod.Labels != nil && pod.Labels["zarf-agent"] == "patched" {
 		// We've already played with this pod, just keep swimming 🐟
-		return &operations.Result{
+		return applyImagePolicy(r, pod, zarfState, podImages(pod), &operations.Result{
 			Allowed:  true,
 			PatchOps: patchOperations,
-		}, nil
+		})
 	}
 
+	// Point the images of the pod at the Zarf registry
+	specOperations, images := mutatePodSpec("/spec", pod.Spec, containerRegistryURL)
+	patchOperations = append(patchOperations, specOperations...)
+
+	// Add a label noting the zarf mutation
+	patchOperations = append(patchOperations, operations.ReplacePatchOperation("/metadata/labels/zarf-agent", "patched"))
+
+	return applyImagePolicy(r, pod, zarfState, images, &operations.Result{
+		Allowed:  true,
+		PatchOps: patchOperations,
+	})
+}
+
+// mutatePodSpec returns the patch operations that add the Zarf image pull secret to a pod spec and point its images at
+// the Zarf registry, along with the images of the pod spec once it has been patched.
+func mutatePodSpec(specPath string, spec corev1.PodSpec, containerRegistryURL string) ([]operations.PatchOperation, []string) {
+	var images []string
+
 	// Add the zarf secret to the podspec
 	zarfSecret := []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}}
-	patchOperations = append(patchOperations, operations.ReplacePatchOperation("/spec/imagePullSecrets", zarfSecret))
+	patchOperations := []operations.PatchOperation{operations.ReplacePatchOperation(specPath+"/imagePullSecrets", zarfSecret)}
 
-	zarfState, err := getStateFromAgentPod(zarfStatePath)
-	if err != nil {
-		return nil, fmt.Errorf(lang.AgentErrGetState, err)
-	}
-	containerRegistryURL := config.GetRegistry(zarfState)
+	swapImage := func(path string, image string) {
+		// Pods created from workloads patched by the Zarf Agent already point at the Zarf registry
+		if utils.IsImageOnHost(image, containerRegistryURL) {
+			images = append(images, image)
+			return
+		}
 
-	// update the image host for each init container
-	for idx, container := range pod.Spec.InitContainers {
-		path := fmt.Sprintf("/spec/initContainers/%d/image", idx)
-		replacement, err := utils.SwapHost(container.Image, containerRegistryURL)
+		replacement, err := utils.SwapHost(image, containerRegistryURL)
 		if err != nil {
-			message.Warnf(lang.AgentErrImageSwap, container.Image)
-			continue // Continue, because we might as well attempt to mutate the other containers for this pod
+			message.Warnf(lang.AgentErrImageSwap, image)
+			images = append(images, image)
+			return // Return, because we might as well attempt to mutate the other containers for this pod
 		}
 		patchOperations = append(patchOperations, operations.ReplacePatchOperation(path, replacement))
+		images = append(images, replacement)
+	}
+
+	// update the image host for each init container
+	for idx, container := range spec.InitContainers {
+		swapImage(fmt.Sprintf("%s/initContainers/%d/image", specPath, idx), container.Image)
 	}
 
 	// update the image host for each ephemeral container
-	for idx, container := range pod.Spec.EphemeralContainers {
-		path := fmt.Sprintf("/spec/ephemeralContainers/%d/image", idx)
-		replacement, err := utils.SwapHost(container.Image, containerRegistryURL)
-		if err != nil {
-			message.Warnf(lang.AgentErrImageSwap, container.Image)
-			continue // Continue, because we might as well attempt to mutate the other containers for this pod
-		}
-		patchOperations = append(patchOperations, operations.ReplacePatchOperation(path, replacement))
+	for idx, container := range spec.EphemeralContainers {
+		swapImage(fmt.Sprintf("%s/ephemeralContainers/%d/image", specPath, idx), container.Image)
 	}
 
 	// update the image host for each normal container
-	for idx, container := range pod.Spec.Containers {
-		path := fmt.Sprintf("/spec/containers/%d/image", idx)
-		replacement, err := utils.SwapHost(container.Image, containerRegistryURL)
-		if err != nil {
-			message.Warnf(lang.AgentErrImageSwap, container.Image)
-			continue // Continue, because we might as well attempt to mutate the other containers for this pod
-		}
-		patchOperations = append(patchOperations, operations.ReplacePatchOperation(path, replacement))
+	for idx, container := range spec.Containers {
+		swapImage(fmt.Sprintf("%s/containers/%d/image", specPath, idx), container.Image)
 	}
 
-	// Add a label noting the zarf mutation
-	patchOperations = append(patchOperations, operations.ReplacePatchOperation("/metadata/labels/zarf-agent", "patched"))
+	return patchOperations, images
+}
 
-	return &operations.Result{
-		Allowed:  true,
-		PatchOps: patchOperations,
-	}, nil
+// podImages returns the images of all the containers of a pod.
+func podImages(pod *corev1.Pod) (images []string) {
+	for _, container := range pod.Spec.InitContainers {
+		images = append(images, container.Image)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		images = append(images, container.Image)
+	}
+	for _, container := range pod.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images
 }
 
-// Reads the state json file that was mounted into the agent pods.
-func getStateFromAgentPod(zarfStatePath string) (types.ZarfState, error) {
-	zarfState := types.ZarfState{}
+// applyImagePolicy rejects (enforce) or reports (audit) a pod with images that were not delivered by a Zarf package.
+func applyImagePolicy(r *v1.AdmissionRequest, pod *corev1.Pod, zarfState types.ZarfState, images []string, result *operations.Result) (*operations.Result, error) {
+	if zarfState.AgentImagePolicy == "" {
+		return result, nil
+	}
 
-	// Read the state file
-	stateFile, err := os.ReadFile(zarfStatePath)
+	violations, err := checkImagePolicy(images, zarfState)
 	if err != nil {
-		return zarfState, err
+		return nil, err
+	}
+
+	for _, image := range violations {
+		msg := fmt.Sprintf(lang.AgentErrImagePolicy, image)
+
+		if zarfState.AgentImagePolicy == config.ZarfAgentImagePolicyEnforce {
+			return &operations.Result{Msg: msg}, nil
+		}
+
+		result.Warnings = append(result.Warnings, msg)
+		recordImagePolicyEvent(pod, r.Namespace, msg)
 	}
 
-	// Unmarshal the json file into a Go struct
-	return zarfState, json.Unmarshal(stateFile, &zarfState)
+	return result, nil
 }
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks provides HTTP handlers for the mutating webhook.
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The reason of the events recorded for pods that do not follow the image policy
+const imagePolicyEventReason = "ZarfImagePolicyViolation"
+
+// packageImages caches the images delivered by deployed Zarf packages, as they are referenced in the cluster.
+var packageImages = struct {
+	sync.Mutex
+	cluster *cluster.Cluster
+	images  map[string]bool
+}{}
+
+// checkImagePolicy returns the images of a pod that were not delivered by a deployed Zarf package.
+func checkImagePolicy(images []string, zarfState types.ZarfState) ([]string, error) {
+	message.Debugf("hooks.checkImagePolicy(%#v)", images)
+
+	packageImages.Lock()
+	defer packageImages.Unlock()
+
+	var violations []string
+	refreshed := false
+
+	for _, image := range images {
+		if packageImages.images[image] {
+			continue
+		}
+
+		// The image might come from a package deployed after the last load, so reload the images once
+		if !refreshed {
+			if err := loadPackageImages(zarfState); err != nil {
+				return nil, err
+			}
+			refreshed = true
+
+			if packageImages.images[image] {
+				continue
+			}
+		}
+
+		violations = append(violations, image)
+	}
+
+	return violations, nil
+}
+
+// loadPackageImages reads the images of the deployed (and currently deploying) Zarf packages from the cluster.
+func loadPackageImages(zarfState types.ZarfState) error {
+	if packageImages.cluster == nil {
+		c, err := cluster.NewCluster()
+		if err != nil || c.Kube == nil {
+			return fmt.Errorf(lang.AgentErrImagePolicyLoad, errors.New("unable to connect to the cluster"))
+		}
+		packageImages.cluster = c
+	}
+
+	images, err := packageImages.cluster.GetPackageImages()
+	if err != nil {
+		return fmt.Errorf(lang.AgentErrImagePolicyLoad, err)
+	}
+
+	registryURL := config.GetRegistry(zarfState)
+	packageImages.images = map[string]bool{}
+	for _, image := range images {
+		replacement, err := utils.SwapHost(image, registryURL)
+		if err != nil {
+			message.Warnf(lang.AgentErrImageSwap, image)
+			continue
+		}
+		packageImages.images[replacement] = true
+	}
+
+	return nil
+}
+
+// recordImagePolicyEvent records a warning event on a pod that does not follow the image policy.
+func recordImagePolicyEvent(pod *corev1.Pod, namespace, msg string) {
+	packageImages.Lock()
+	c := packageImages.cluster
+	packageImages.Unlock()
+
+	if c == nil {
+		return
+	}
+
+	// Pods created by controllers only have a generated name at admission
+	name := pod.Name
+	if name == "" {
+		name = pod.GenerateName
+	}
+
+	object := corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Name:       name,
+		Namespace:  namespace,
+		UID:        pod.UID,
+	}
+
+	if _, err := c.Kube.CreateEvent(object, "zarf-agent", corev1.EventTypeWarning, imagePolicyEventReason, msg); err != nil {
+		message.Warnf(lang.AgentErrImagePolicyEvent, err.Error())
+	}
+}
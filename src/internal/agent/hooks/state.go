@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// agentState caches the Zarf state watched from the zarf-state secret.
+var agentState = struct {
+	sync.RWMutex
+	state  types.ZarfState
+	loaded bool
+}{}
+
+// WatchZarfState starts an informer that keeps the cached Zarf state in sync with the zarf-state secret.
+func WatchZarfState(stopCh <-chan struct{}) error {
+	message.Debug("hooks.WatchZarfState()")
+
+	c, err := cluster.NewCluster()
+	if err != nil || c.Kube == nil {
+		return errors.New(lang.AgentErrConnectCluster)
+	}
+
+	// Only watch the zarf-state secret instead of every secret in the namespace
+	factory := informers.NewSharedInformerFactoryWithOptions(c.Kube.Clientset, 0,
+		informers.WithNamespace(cluster.ZarfNamespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", cluster.ZarfStateSecretName).String()
+		}),
+	)
+
+	informer := factory.Core().V1().Secrets().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			setZarfState(c, obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			setZarfState(c, obj)
+		},
+		// Zarf saves the state by deleting and recreating the secret, so keep the last state until it is added again
+		DeleteFunc: func(_ interface{}) {
+			message.Debug("The zarf-state secret was deleted, keeping the last loaded state")
+		},
+	})
+
+	factory.Start(stopCh)
+	return nil
+}
+
+// IsZarfStateLoaded returns true once the Zarf state has been read from the cluster.
+func IsZarfStateLoaded() bool {
+	agentState.RLock()
+	defer agentState.RUnlock()
+	return agentState.loaded
+}
+
+// getZarfState returns the cached Zarf state.
+func getZarfState() (types.ZarfState, error) {
+	agentState.RLock()
+	defer agentState.RUnlock()
+
+	if !agentState.loaded {
+		return agentState.state, errors.New(lang.AgentErrStateNotLoaded)
+	}
+
+	return agentState.state, nil
+}
+
+// setZarfState parses and decrypts the Zarf state from an updated zarf-state secret.
+func setZarfState(c *cluster.Cluster, obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+
+	state, err := c.DecodeZarfState(secret)
+	if err != nil {
+		message.Warnf(lang.AgentErrParseState, err.Error())
+		return
+	}
+
+	agentState.Lock()
+	agentState.state = state
+	agentState.loaded = true
+	agentState.Unlock()
+
+	// The registry might have changed, so the package images are loaded again on the next request
+	packageImages.Lock()
+	packageImages.images = nil
+	packageImages.Unlock()
+
+	message.Info(lang.AgentInfoStateLoaded)
+}
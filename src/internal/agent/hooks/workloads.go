@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/agent/operations"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	v1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewWorkloadMutationHook creates a new instance of the workload controller (Deployment, StatefulSet, DaemonSet, Job
+// and CronJob) mutation hook.
+func NewWorkloadMutationHook() operations.Hook {
+	message.Debug("hooks.NewWorkloadMutationHook()")
+	return operations.Hook{
+		Create: mutateWorkload,
+		Update: mutateWorkload,
+	}
+}
+
+// mutateWorkload points the images in the pod template of a workload controller at the Zarf registry.
+func mutateWorkload(r *v1.AdmissionRequest) (*operations.Result, error) {
+	message.Debugf("hooks.mutateWorkload()(*v1.AdmissionRequest) - %#v , %s/%s: %#v", r.Kind, r.Namespace, r.Name, r.Operation)
+
+	templatePath, template, err := parseWorkloadTemplate(r.Kind.Kind, r.Object.Raw)
+	if err != nil {
+		return &operations.Result{Msg: err.Error()}, nil
+	}
+
+	zarfState, err := getZarfState()
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrGetState, err)
+	}
+
+	// Templates are not labeled like pods, images that already point at the Zarf registry are left alone instead
+	patchOperations, _ := mutatePodSpec(templatePath+"/spec", template.Spec, config.GetRegistry(zarfState))
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patchOperations,
+	}, nil
+}
+
+// parseWorkloadTemplate returns the JSON patch path and the contents of the pod template of a workload controller.
+func parseWorkloadTemplate(kind string, object []byte) (string, corev1.PodTemplateSpec, error) {
+	var (
+		target   any
+		template *corev1.PodTemplateSpec
+		path     = "/spec/template"
+	)
+
+	switch kind {
+	case "Deployment":
+		workload := &appsv1.Deployment{}
+		target, template = workload, &workload.Spec.Template
+	case "StatefulSet":
+		workload := &appsv1.StatefulSet{}
+		target, template = workload, &workload.Spec.Template
+	case "DaemonSet":
+		workload := &appsv1.DaemonSet{}
+		target, template = workload, &workload.Spec.Template
+	case "Job":
+		workload := &batchv1.Job{}
+		target, template = workload, &workload.Spec.Template
+	case "CronJob":
+		workload := &batchv1.CronJob{}
+		target, template = workload, &workload.Spec.JobTemplate.Spec.Template
+		path = "/spec/jobTemplate/spec/template"
+	default:
+		return "", corev1.PodTemplateSpec{}, fmt.Errorf(lang.AgentErrUnsupportedKind, kind)
+	}
+
+	if err := json.Unmarshal(object, target); err != nil {
+		return "", corev1.PodTemplateSpec{}, fmt.Errorf(lang.ErrUnmarshal, err)
+	}
+
+	return path, *template, nil
+}
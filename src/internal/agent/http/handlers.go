@@ -11,6 +11,7 @@ import (
 	"net/http"
 
 	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/agent/hooks"
 	"github.com/defenseunicorns/zarf/src/internal/agent/operations"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	v1 "k8s.io/api/admission/v1"
@@ -34,7 +35,7 @@ func newAdmissionHandler() *admissionHandler {
 // Serve returns a http.HandlerFunc for an admission webhook.
 func (h *admissionHandler) Serve(hook operations.Hook) http.HandlerFunc {
 	message.Debugf("http.Serve(%#v)", hook)
-	return func(w http.ResponseWriter, r *http.Request) {
+	return instrument(func(w http.ResponseWriter, r *http.Request) {
 		message.Debugf("http.Serve()(writer, %#v)", r.URL)
 
 		w.Header().Set("Content-Type", "application/json")
@@ -78,9 +79,10 @@ func (h *admissionHandler) Serve(hook operations.Hook) http.HandlerFunc {
 				Kind:       "AdmissionReview",
 			},
 			Response: &v1.AdmissionResponse{
-				UID:     review.Request.UID,
-				Allowed: result.Allowed,
-				Result:  &meta.Status{Message: result.Msg},
+				UID:      review.Request.UID,
+				Allowed:  result.Allowed,
+				Result:   &meta.Status{Message: result.Msg},
+				Warnings: result.Warnings,
 			},
 		}
 
@@ -107,9 +109,10 @@ func (h *admissionHandler) Serve(hook operations.Hook) http.HandlerFunc {
 		message.Debug("RESPONSE: ", string(jsonResponse))
 
 		message.Infof(lang.AgentInfoWebhookAllowed, r.URL.Path, review.Request.Operation, result.Allowed)
+		patchesTotal.WithLabelValues(r.URL.Path).Add(float64(len(result.PatchOps)))
 		w.WriteHeader(http.StatusOK)
 		w.Write(jsonResponse)
-	}
+	})
 }
 
 func healthz() http.HandlerFunc {
@@ -118,3 +121,15 @@ func healthz() http.HandlerFunc {
 		w.Write([]byte("ok"))
 	}
 }
+
+// readyz reports the agent as ready once the Zarf state has been loaded from the cluster.
+func readyz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !hooks.IsZarfStateLoaded() {
+			http.Error(w, lang.AgentErrStateNotLoaded, http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package http provides a http server for the agent.
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics of the admission webhooks, labeled by the path of the hook.
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "zarf_agent",
+		Name:      "requests_total",
+		Help:      "Number of admission requests received by each hook.",
+	}, []string{"hook"})
+
+	patchesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "zarf_agent",
+		Name:      "patches_total",
+		Help:      "Number of JSON patch operations returned by each hook.",
+	}, []string{"hook"})
+
+	errorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "zarf_agent",
+		Name:      "errors_total",
+		Help:      "Number of admission requests each hook failed to process.",
+	}, []string{"hook"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "zarf_agent",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken by each hook to process an admission request.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"hook"})
+)
+
+// statusRecorder keeps the status code written to a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before writing it.
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// instrument records the request, error and latency metrics of a hook handler.
+func instrument(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hook := r.URL.Path
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next(recorder, r)
+
+		requestsTotal.WithLabelValues(hook).Inc()
+		requestDuration.WithLabelValues(hook).Observe(time.Since(start).Seconds())
+		if recorder.status >= http.StatusBadRequest {
+			errorsTotal.WithLabelValues(hook).Inc()
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/defenseunicorns/zarf/src/internal/agent/hooks"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // NewServer creates and return a http.Server.
@@ -18,14 +19,26 @@ func NewServer(port string) *http.Server {
 
 	// Instances hooks
 	podsMutation := hooks.NewPodMutationHook()
+	workloadsMutation := hooks.NewWorkloadMutationHook()
 	gitRepositoryMutation := hooks.NewGitRepositoryMutationHook()
+	helmRepositoryMutation := hooks.NewHelmRepositoryMutationHook()
+	ociRepositoryMutation := hooks.NewOCIRepositoryMutationHook()
+	argoApplicationMutation := hooks.NewArgoApplicationMutationHook()
+	argoRepositoryMutation := hooks.NewArgoRepositoryMutationHook()
 
 	// Routers
 	ah := newAdmissionHandler()
 	mux := http.NewServeMux()
 	mux.Handle("/healthz", healthz())
+	mux.Handle("/readyz", readyz())
+	mux.Handle("/metrics", promhttp.Handler())
 	mux.Handle("/mutate/pod", ah.Serve(podsMutation))
+	mux.Handle("/mutate/workload", ah.Serve(workloadsMutation))
 	mux.Handle("/mutate/flux-gitrepository", ah.Serve(gitRepositoryMutation))
+	mux.Handle("/mutate/flux-helmrepository", ah.Serve(helmRepositoryMutation))
+	mux.Handle("/mutate/flux-ocirepository", ah.Serve(ociRepositoryMutation))
+	mux.Handle("/mutate/argocd-application", ah.Serve(argoApplicationMutation))
+	mux.Handle("/mutate/argocd-repository", ah.Serve(argoRepositoryMutation))
 
 	return &http.Server{
 		Addr:    fmt.Sprintf(":%s", port),
@@ -12,6 +12,7 @@ import (
 	"syscall"
 
 	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/agent/hooks"
 	agentHttp "github.com/defenseunicorns/zarf/src/internal/agent/http"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 )
@@ -30,6 +31,13 @@ const (
 func StartWebhook() {
 	message.Debug("agent.StartWebhook()")
 
+	// Keep the Zarf state in sync with the cluster, the agent reports ready once it is loaded
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	if err := hooks.WatchZarfState(stopCh); err != nil {
+		message.Fatal(err, lang.AgentErrStart)
+	}
+
 	server := agentHttp.NewServer(httpPort)
 	go func() {
 		if err := server.ListenAndServeTLS(tlsCert, tlsKey); err != nil && err != http.ErrServerClosed {
@@ -21,6 +21,7 @@ func ReadState(w http.ResponseWriter, _ *http.Request) {
 	data, err := cluster.NewClusterOrDie().LoadZarfState()
 	if err != nil {
 		message.ErrorWebf(err, w, lang.ErrLoadState)
+		return
 	}
 
 	if data.Distro == "" {
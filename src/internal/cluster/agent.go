@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/k8s"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/pki"
+	"github.com/defenseunicorns/zarf/src/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// The Zarf Agent certificate is renewed once it expires within this window
+	agentTLSRenewBefore = 30 * 24 * time.Hour
+
+	agentTLSSecretName     = "agent-hook-tls"
+	agentDeploymentName    = "agent-hook"
+	agentWebhookConfigName = "zarf"
+)
+
+// IsAgentTLSExpiring returns true if the Zarf Agent certificate in the state expires soon or cannot be read.
+func IsAgentTLSExpiring(state types.ZarfState) bool {
+	expiration, err := pki.GetCertExpiration(state.AgentTLS.Cert)
+	if err != nil {
+		message.Debugf("Unable to read the Zarf Agent certificate: %s", err.Error())
+		return true
+	}
+
+	return time.Until(expiration) < agentTLSRenewBefore
+}
+
+// RotateAgentTLS generates a new PKI for the Zarf Agent, rolls it out to the agent by updating its TLS secret and the
+// webhook CA bundle and restarting its pods, and then saves it to the state. If any step fails the agent is put back on
+// its previous PKI and the state is left unchanged, so an expiring certificate is still detected on the next run.
+func (c *Cluster) RotateAgentTLS(state types.ZarfState) (types.ZarfState, error) {
+	message.Debug("cluster.RotateAgentTLS()")
+
+	oldTLS := state.AgentTLS
+	newTLS := pki.GeneratePKI(config.ZarfAgentHost)
+
+	rolledOut, err := c.rolloutAgentTLS(oldTLS, newTLS)
+	if err != nil {
+		if rolledOut {
+			c.undoAgentTLS(newTLS, oldTLS)
+		}
+		return state, err
+	}
+
+	newState := state
+	newState.AgentTLS = newTLS
+	if err := c.SaveZarfState(newState); err != nil {
+		if rolledOut {
+			c.undoAgentTLS(newTLS, oldTLS)
+		}
+		return state, err
+	}
+
+	return newState, nil
+}
+
+// rolloutAgentTLS moves the Zarf Agent from one PKI to another and returns whether the agent TLS secret was changed.
+// If the agent is not deployed yet there is nothing to roll out, it will use the PKI from the state once it is.
+func (c *Cluster) rolloutAgentTLS(from, to k8s.GeneratedPKI) (bool, error) {
+	secret, err := c.Kube.GetSecret(ZarfNamespace, agentTLSSecretName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("unable to get the Zarf Agent TLS secret: %w", err)
+	}
+
+	// Update the secret in place so it stays owned by the init package
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[corev1.TLSCertKey] = to.Cert
+	secret.Data[corev1.TLSPrivateKeyKey] = to.Key
+	if _, err := c.Kube.UpdateSecret(secret); err != nil {
+		return false, fmt.Errorf("unable to update the Zarf Agent TLS secret: %w", err)
+	}
+
+	// Trust both CAs while the agent pods restart so admission keeps working during the rollout
+	if err := c.setAgentCABundle(append(append([]byte{}, to.CA...), from.CA...)); err != nil {
+		return true, err
+	}
+
+	if err := c.Kube.RestartDeployment(ZarfNamespace, agentDeploymentName); err != nil {
+		return true, fmt.Errorf("unable to restart the Zarf Agent: %w", err)
+	}
+
+	if err := c.Kube.WaitForDeploymentRollout(ZarfNamespace, agentDeploymentName, 5*time.Minute); err != nil {
+		return true, fmt.Errorf("unable to restart the Zarf Agent: %w", err)
+	}
+
+	return true, c.setAgentCABundle(to.CA)
+}
+
+// undoAgentTLS puts the Zarf Agent back on its previous PKI after a failed rotation.
+func (c *Cluster) undoAgentTLS(from, to k8s.GeneratedPKI) {
+	if _, err := c.rolloutAgentTLS(from, to); err != nil {
+		message.Warnf("Unable to restore the previous Zarf Agent certificate: %s", err.Error())
+	}
+}
+
+// setAgentCABundle sets the CA bundle the Kubernetes API server uses to trust the Zarf Agent webhooks.
+func (c *Cluster) setAgentCABundle(caBundle []byte) error {
+	webhookConfig, err := c.Kube.GetMutatingWebhookConfiguration(agentWebhookConfigName)
+	if err != nil {
+		return fmt.Errorf("unable to get the Zarf Agent webhook configuration: %w", err)
+	}
+
+	for idx := range webhookConfig.Webhooks {
+		webhookConfig.Webhooks[idx].ClientConfig.CABundle = caBundle
+	}
+
+	if _, err := c.Kube.UpdateMutatingWebhookConfiguration(webhookConfig); err != nil {
+		return fmt.Errorf("unable to update the Zarf Agent webhook configuration: %w", err)
+	}
+
+	return nil
+}
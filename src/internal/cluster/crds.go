@@ -0,0 +1,545 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	zarfAPIGroup   = "zarf.dev"
+	zarfAPIVersion = "v1alpha1"
+
+	zarfStateKind   = "ZarfState"
+	zarfPackageKind = "ZarfDeployedPackage"
+
+	// Phases and conditions of a ZarfDeployedPackage
+	packagePhaseDeploying   = "Deploying"
+	packagePhaseDeployed    = "Deployed"
+	packagePhaseRemoving    = "Removing"
+	packagePhaseFailed      = "Failed"
+	packageConditionDeploy  = "Deployed"
+	packageConditionPending = "Deploying"
+	packageConditionRemove  = "Removing"
+)
+
+var (
+	zarfStateResource   = schema.GroupVersionResource{Group: zarfAPIGroup, Version: zarfAPIVersion, Resource: "zarfstates"}
+	zarfPackageResource = schema.GroupVersionResource{Group: zarfAPIGroup, Version: zarfAPIVersion, Resource: "zarfdeployedpackages"}
+)
+
+// zarfStateObject is the ZarfState custom resource, it holds the Zarf state without its credentials.
+type zarfStateObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   types.ZarfState `json:"spec"`
+	Status struct {
+		LastUpdated metav1.Time `json:"lastUpdated"`
+	} `json:"status"`
+}
+
+// zarfPackageObject is the ZarfDeployedPackage custom resource, it holds the record of a deployed package.
+type zarfPackageObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   types.DeployedPackage `json:"spec"`
+	Status zarfPackageStatus     `json:"status"`
+}
+
+// zarfPackageStatus is the status of a ZarfDeployedPackage.
+type zarfPackageStatus struct {
+	Phase        string             `json:"phase,omitempty"`
+	Components   string             `json:"components,omitempty"`
+	LastDeployed *metav1.Time       `json:"lastDeployed,omitempty"`
+	Conditions   []metav1.Condition `json:"conditions,omitempty"`
+	// The package being deployed, so the Zarf Agent image policy allows its images before it is recorded
+	Pending *types.DeployedPackage `json:"pending,omitempty"`
+	// The recorded deployment generations of the package, oldest first
+	History []zarfPackageGeneration `json:"history,omitempty"`
+}
+
+// zarfPackageGeneration is a recorded deployment generation of a package. Only what a rollback needs is kept instead
+// of the whole package definition, so the history cannot push the resource over the object size limit of etcd.
+type zarfPackageGeneration struct {
+	Generation         int                       `json:"generation"`
+	CLIVersion         string                    `json:"cliVersion"`
+	PackageVersion     string                    `json:"packageVersion,omitempty"`
+	DeployedComponents []types.DeployedComponent `json:"deployedComponents"`
+}
+
+// newPackageGeneration returns the history entry of a deployment generation of a package.
+func newPackageGeneration(deployedPackage types.DeployedPackage) zarfPackageGeneration {
+	return zarfPackageGeneration{
+		Generation:         deployedPackage.Generation,
+		CLIVersion:         deployedPackage.CLIVersion,
+		PackageVersion:     deployedPackage.Data.Metadata.Version,
+		DeployedComponents: deployedPackage.DeployedComponents,
+	}
+}
+
+// getHistory returns the recorded deployment generations of the package. The history does not keep the package
+// definition of each generation, so every generation gets the one of the latest deployment with its version.
+func (object zarfPackageObject) getHistory() []types.DeployedPackage {
+	var history []types.DeployedPackage
+	for _, generation := range object.Status.History {
+		deployedPackage := types.DeployedPackage{
+			Name:               object.Spec.Name,
+			Data:               object.Spec.Data,
+			CLIVersion:         generation.CLIVersion,
+			Generation:         generation.Generation,
+			DeployedComponents: generation.DeployedComponents,
+		}
+		if generation.PackageVersion != "" {
+			deployedPackage.Data.Metadata.Version = generation.PackageVersion
+		}
+		history = append(history, deployedPackage)
+	}
+
+	return history
+}
+
+// UsesZarfCRDs returns true if the Zarf state and deployed packages are stored in Zarf custom resources.
+func (c *Cluster) UsesZarfCRDs() bool {
+	_, err := c.Kube.GetCustomResourceDefinition(zarfPackageResource.GroupResource().String())
+	return err == nil
+}
+
+// InstallZarfCRDs installs the ZarfState and ZarfDeployedPackage custom resource definitions and moves the packages
+// recorded in secrets to ZarfDeployedPackage resources. The state is moved the next time it is saved.
+func (c *Cluster) InstallZarfCRDs() error {
+	message.Debug("cluster.InstallZarfCRDs()")
+
+	crds := []*apiextensionsv1.CustomResourceDefinition{
+		zarfCRD(zarfStateKind, zarfStateResource, nil, []apiextensionsv1.CustomResourceColumnDefinition{
+			{Name: "Distro", Type: "string", JSONPath: ".spec.distro"},
+			{Name: "Architecture", Type: "string", JSONPath: ".spec.architecture"},
+			{Name: "Registry", Type: "string", JSONPath: ".spec.registryInfo.address"},
+			{Name: "Git Server", Type: "string", JSONPath: ".spec.gitServer.address"},
+			{Name: "Updated", Type: "date", JSONPath: ".status.lastUpdated"},
+		}),
+		zarfCRD(zarfPackageKind, zarfPackageResource, []string{"zarfpackages", "zpkg"}, []apiextensionsv1.CustomResourceColumnDefinition{
+			{Name: "Package", Type: "string", JSONPath: ".spec.name"},
+			{Name: "Version", Type: "string", JSONPath: ".spec.data.metadata.version"},
+			{Name: "Components", Type: "string", JSONPath: ".status.components"},
+			{Name: "CLI Version", Type: "string", JSONPath: ".spec.cliVersion"},
+			{Name: "Status", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Deployed", Type: "date", JSONPath: ".status.lastDeployed"},
+			{Name: "Generation", Type: "integer", JSONPath: ".spec.generation", Priority: 1},
+		}),
+	}
+
+	for _, crd := range crds {
+		if err := c.Kube.ApplyCustomResourceDefinition(crd); err != nil {
+			return fmt.Errorf("unable to install the %s custom resource definition: %w", crd.Spec.Names.Kind, err)
+		}
+
+		if err := c.Kube.WaitForCustomResourceDefinition(crd.Name, time.Minute); err != nil {
+			return err
+		}
+	}
+
+	return c.migratePackageSecrets()
+}
+
+// DeleteZarfCRDs removes the Zarf custom resource definitions and all of their resources.
+func (c *Cluster) DeleteZarfCRDs() error {
+	for _, resource := range []schema.GroupVersionResource{zarfStateResource, zarfPackageResource} {
+		if err := c.Kube.DeleteCustomResourceDefinition(resource.GroupResource().String()); err != nil {
+			return fmt.Errorf("unable to remove the %s custom resource definition: %w", resource.Resource, err)
+		}
+	}
+
+	return nil
+}
+
+// zarfCRD returns the definition of a Zarf custom resource whose spec and status are not validated by the cluster.
+func zarfCRD(kind string, resource schema.GroupVersionResource, shortNames []string, columns []apiextensionsv1.CustomResourceColumnDefinition) *apiextensionsv1.CustomResourceDefinition {
+	preserveUnknownFields := true
+	object := apiextensionsv1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: &preserveUnknownFields}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   resource.GroupResource().String(),
+			Labels: labels,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: resource.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:       kind,
+				ListKind:   kind + "List",
+				Plural:     resource.Resource,
+				Singular:   strings.ToLower(kind),
+				ShortNames: shortNames,
+				Categories: []string{"zarf"},
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    resource.Version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec":   object,
+								"status": object,
+							},
+						},
+					},
+					AdditionalPrinterColumns: columns,
+				},
+			},
+		},
+	}
+}
+
+// getZarfStateObject returns the Zarf state stored in the ZarfState resource, without its credentials.
+func (c *Cluster) getZarfStateObject() (types.ZarfState, error) {
+	var object zarfStateObject
+
+	resource, err := c.Kube.GetCustomResource(zarfStateResource, ZarfNamespace, ZarfStateSecretName)
+	if err != nil {
+		return object.Spec, err
+	}
+
+	err = fromUnstructured(resource, &object)
+	return object.Spec, err
+}
+
+// saveZarfStateObject stores the Zarf state without its credentials in the ZarfState resource.
+func (c *Cluster) saveZarfStateObject(state types.ZarfState) error {
+	extractStateSecrets(&state)
+
+	// The state is saved before anything else is deployed to the zarf namespace during init
+	if _, err := c.Kube.CreateNamespace(ZarfNamespace, nil); err != nil {
+		return fmt.Errorf("unable to create or read the namespace: %w", err)
+	}
+
+	object := zarfStateObject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: zarfStateResource.GroupVersion().String(), Kind: zarfStateKind},
+		ObjectMeta: metav1.ObjectMeta{Name: ZarfStateSecretName, Namespace: ZarfNamespace, Labels: labels},
+		Spec:       state,
+	}
+	object.Status.LastUpdated = metav1.Now()
+
+	return c.applyObject(zarfStateResource, object)
+}
+
+// getPackageObjects returns the ZarfDeployedPackage resources of every package.
+func (c *Cluster) getPackageObjects() ([]zarfPackageObject, error) {
+	var objects []zarfPackageObject
+
+	resources, err := c.Kube.GetCustomResources(zarfPackageResource, ZarfNamespace)
+	if err != nil {
+		return objects, err
+	}
+
+	for idx := range resources.Items {
+		var object zarfPackageObject
+		if err := fromUnstructured(&resources.Items[idx], &object); err != nil {
+			return objects, err
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// getPackageObject returns the ZarfDeployedPackage resource of a package, or a new one if it does not exist yet.
+func (c *Cluster) getPackageObject(packageName string) (zarfPackageObject, error) {
+	object := zarfPackageObject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: zarfPackageResource.GroupVersion().String(), Kind: zarfPackageKind},
+		ObjectMeta: metav1.ObjectMeta{Name: packageName, Namespace: ZarfNamespace, Labels: labels},
+	}
+
+	resource, err := c.Kube.GetCustomResource(zarfPackageResource, ZarfNamespace, packageName)
+	if errors.IsNotFound(err) {
+		return object, nil
+	} else if err != nil {
+		return object, err
+	}
+
+	err = fromUnstructured(resource, &object)
+	return object, err
+}
+
+// recordPackageObject records a deployment generation of a package in its ZarfDeployedPackage resource.
+func (c *Cluster) recordPackageObject(deployedPackage types.DeployedPackage) error {
+	object, err := c.getPackageObject(deployedPackage.Name)
+	if err != nil {
+		return err
+	}
+
+	// Packages deployed before generations were recorded have no history, so keep them as the first generation
+	if object.Spec.Name != "" && object.Spec.Generation == 0 {
+		object.Spec.Generation = 1
+		object.Status.History = append(object.Status.History, newPackageGeneration(object.Spec))
+	}
+
+	history := []zarfPackageGeneration{}
+	for _, generation := range object.Status.History {
+		if generation.Generation > deployedPackage.Generation-packageHistoryLimit && generation.Generation != deployedPackage.Generation {
+			history = append(history, generation)
+		}
+	}
+
+	now := metav1.Now()
+	object.Spec = deployedPackage
+	object.Status.History = append(history, newPackageGeneration(deployedPackage))
+	object.Status.Components = componentNames(deployedPackage.DeployedComponents)
+	object.Status.LastDeployed = &now
+	if object.Status.Pending == nil {
+		object.Status.Phase = packagePhaseDeployed
+	}
+
+	meta.SetStatusCondition(&object.Status.Conditions, metav1.Condition{
+		Type:    packageConditionDeploy,
+		Status:  metav1.ConditionTrue,
+		Reason:  "DeploymentRecorded",
+		Message: fmt.Sprintf("Generation %d was deployed by Zarf %s", deployedPackage.Generation, deployedPackage.CLIVersion),
+	})
+
+	return c.applyObject(zarfPackageResource, object)
+}
+
+// recordFailedPackageObject saves the record of a package after a failed deployment, keeping its history.
+func (c *Cluster) recordFailedPackageObject(deployedPackage types.DeployedPackage) error {
+	object, err := c.getPackageObject(deployedPackage.Name)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	object.Spec = deployedPackage
+	object.Status.Components = componentNames(deployedPackage.DeployedComponents)
+	object.Status.LastDeployed = &now
+	object.Status.Phase = packagePhaseFailed
+
+	meta.SetStatusCondition(&object.Status.Conditions, metav1.Condition{
+		Type:    packageConditionDeploy,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DeploymentFailed",
+		Message: fmt.Sprintf("The deployment by Zarf %s failed, generation %d is still the latest", deployedPackage.CLIVersion, deployedPackage.Generation),
+	})
+
+	return c.applyObject(zarfPackageResource, object)
+}
+
+// recordPendingPackageObject records the package being deployed in its ZarfDeployedPackage resource.
+func (c *Cluster) recordPendingPackageObject(deployedPackage types.DeployedPackage) error {
+	object, err := c.getPackageObject(deployedPackage.Name)
+	if err != nil {
+		return err
+	}
+
+	object.Status.Pending = &deployedPackage
+	object.Status.Phase = packagePhaseDeploying
+
+	meta.SetStatusCondition(&object.Status.Conditions, metav1.Condition{
+		Type:    packageConditionPending,
+		Status:  metav1.ConditionTrue,
+		Reason:  "DeploymentStarted",
+		Message: fmt.Sprintf("Deploying the components %s", componentNames(deployedPackage.DeployedComponents)),
+	})
+
+	return c.applyObject(zarfPackageResource, object)
+}
+
+// clearPendingPackageObject removes the package being deployed from its ZarfDeployedPackage resource, removing the
+// resource if the package was never recorded as deployed.
+func (c *Cluster) clearPendingPackageObject(packageName string) error {
+	object, err := c.getPackageObject(packageName)
+	if err != nil {
+		return err
+	}
+
+	if object.Spec.Name == "" {
+		return c.Kube.DeleteCustomResource(zarfPackageResource, ZarfNamespace, packageName)
+	}
+
+	object.Status.Pending = nil
+	// A failed deployment stays visible until the package is deployed again
+	if object.Status.Phase != packagePhaseFailed {
+		object.Status.Phase = packagePhaseDeployed
+	}
+
+	meta.SetStatusCondition(&object.Status.Conditions, metav1.Condition{
+		Type:    packageConditionPending,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DeploymentFinished",
+		Message: "No deployment is in progress",
+	})
+
+	return c.applyObject(zarfPackageResource, object)
+}
+
+// updatePackageObject saves a package record after some of its components were removed.
+func (c *Cluster) updatePackageObject(deployedPackage types.DeployedPackage, removedComponents []string) error {
+	object, err := c.getPackageObject(deployedPackage.Name)
+	if err != nil {
+		return err
+	}
+
+	object.Spec = deployedPackage
+	object.Status.Components = componentNames(deployedPackage.DeployedComponents)
+	object.Status.Phase = packagePhaseDeployed
+
+	meta.SetStatusCondition(&object.Status.Conditions, metav1.Condition{
+		Type:    packageConditionRemove,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ComponentsRemoved",
+		Message: fmt.Sprintf("Removed the components %s", strings.Join(removedComponents, ",")),
+	})
+
+	return c.applyObject(zarfPackageResource, object)
+}
+
+// markPackageObjectRemoving sets the ZarfDeployedPackage resource of a package as being removed.
+func (c *Cluster) markPackageObjectRemoving(packageName string) error {
+	object, err := c.getPackageObject(packageName)
+	if err != nil || object.Spec.Name == "" {
+		return err
+	}
+
+	object.Status.Phase = packagePhaseRemoving
+
+	meta.SetStatusCondition(&object.Status.Conditions, metav1.Condition{
+		Type:    packageConditionRemove,
+		Status:  metav1.ConditionTrue,
+		Reason:  "RemovalStarted",
+		Message: "Removing the package components",
+	})
+
+	return c.applyObject(zarfPackageResource, object)
+}
+
+// migratePackageSecrets moves the deployed packages, their history and pending deployments from secrets to
+// ZarfDeployedPackage resources.
+func (c *Cluster) migratePackageSecrets() error {
+	objects := map[string]*zarfPackageObject{}
+	getObject := func(packageName string) (*zarfPackageObject, error) {
+		if object, ok := objects[packageName]; ok {
+			return object, nil
+		}
+		object, err := c.getPackageObject(packageName)
+		objects[packageName] = &object
+		return &object, err
+	}
+
+	var migrated []*corev1.Secret
+	for _, label := range []string{"package-deploy-info", packageHistoryLabel, packagePendingLabel} {
+		secrets, err := c.Kube.GetSecretsWithLabel(ZarfNamespace, label)
+		if err != nil {
+			return err
+		}
+
+		for idx, secret := range secrets.Items {
+			var deployedPackage types.DeployedPackage
+			if err := json.Unmarshal(secret.Data["data"], &deployedPackage); err != nil {
+				return fmt.Errorf("unable to read the package secret %s: %w", secret.Name, err)
+			}
+
+			object, err := getObject(deployedPackage.Name)
+			if err != nil {
+				return err
+			}
+
+			switch label {
+			case packageHistoryLabel:
+				object.Status.History = append(object.Status.History, newPackageGeneration(deployedPackage))
+			case packagePendingLabel:
+				object.Status.Pending = &deployedPackage
+			default:
+				object.Spec = deployedPackage
+				object.Status.Components = componentNames(deployedPackage.DeployedComponents)
+				object.Status.Phase = packagePhaseDeployed
+				meta.SetStatusCondition(&object.Status.Conditions, metav1.Condition{
+					Type:    packageConditionDeploy,
+					Status:  metav1.ConditionTrue,
+					Reason:  "MigratedFromSecret",
+					Message: fmt.Sprintf("Generation %d was deployed by Zarf %s", deployedPackage.Generation, deployedPackage.CLIVersion),
+				})
+			}
+
+			migrated = append(migrated, &secrets.Items[idx])
+		}
+	}
+
+	for packageName, object := range objects {
+		message.Debugf("Moving the records of the package %s to a ZarfDeployedPackage", packageName)
+		if err := c.applyObject(zarfPackageResource, object); err != nil {
+			return fmt.Errorf("unable to save the ZarfDeployedPackage %s: %w", packageName, err)
+		}
+	}
+
+	// Only remove the secrets once every package has been moved
+	for _, secret := range migrated {
+		if err := c.Kube.DeleteSecret(secret); err != nil {
+			return fmt.Errorf("unable to remove the package secret %s: %w", secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyObject creates or replaces a Zarf custom resource.
+func (c *Cluster) applyObject(resource schema.GroupVersionResource, object any) error {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	var unstructuredObject unstructured.Unstructured
+	if err := unstructuredObject.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	return c.Kube.ApplyCustomResource(resource, &unstructuredObject)
+}
+
+// fromUnstructured converts a Zarf custom resource read from the cluster to its type.
+func fromUnstructured(resource *unstructured.Unstructured, object any) error {
+	data, err := resource.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, object); err != nil {
+		return fmt.Errorf("unable to read the %s %s: %w", resource.GetKind(), resource.GetName(), err)
+	}
+
+	return nil
+}
+
+// componentNames returns the comma separated names of the deployed components.
+func componentNames(components []types.DeployedComponent) string {
+	var names []string
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// deployedPackageNotFound returns the error for a package that has no ZarfDeployedPackage resource.
+func deployedPackageNotFound(packageName string) error {
+	return errors.NewNotFound(zarfPackageResource.GroupResource(), packageName)
+}
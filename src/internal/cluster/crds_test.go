@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageObjectHistory(t *testing.T) {
+	newDeployedPackage := func(version string, generation int, revision int) types.DeployedPackage {
+		return types.DeployedPackage{
+			Name:       "podinfo",
+			CLIVersion: "v0.24.0",
+			Generation: generation,
+			Data: types.ZarfPackage{
+				Metadata:   types.ZarfMetadata{Name: "podinfo", Version: version},
+				Components: []types.ZarfComponent{{Name: "podinfo", Images: []string{"ghcr.io/stefanprodan/podinfo:" + version}}},
+			},
+			DeployedComponents: []types.DeployedComponent{
+				{Name: "podinfo", InstalledCharts: []types.InstalledChart{{Namespace: "podinfo", ChartName: "podinfo", Revision: revision}}},
+			},
+		}
+	}
+
+	first := newDeployedPackage("6.3.0", 1, 1)
+	second := newDeployedPackage("6.3.3", 2, 2)
+
+	object := zarfPackageObject{Spec: second}
+	object.Status.History = []zarfPackageGeneration{newPackageGeneration(first), newPackageGeneration(second)}
+
+	// The history only holds the components and chart revisions of each generation, not the package definition
+	data, err := json.Marshal(object.Status.History)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "podinfo:6.3.0")
+
+	history := object.getHistory()
+	require.Len(t, history, 2)
+	require.Equal(t, 1, history[0].Generation)
+	require.Equal(t, "6.3.0", history[0].Data.Metadata.Version)
+	require.Equal(t, first.DeployedComponents, history[0].DeployedComponents)
+	require.Equal(t, second.Data.Components, history[0].Data.Components)
+	require.Equal(t, second, history[1])
+
+	// Generations recorded with the whole package definition are still read
+	var fullHistory []zarfPackageGeneration
+	data, err = json.Marshal([]types.DeployedPackage{first})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &fullHistory))
+	require.Equal(t, []zarfPackageGeneration{{Generation: 1, CLIVersion: "v0.24.0", DeployedComponents: first.DeployedComponents}}, fullHistory)
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	registrySecretName     = "zarf-docker-registry-secret"
+	registryDeploymentName = "zarf-docker-registry"
+	registryHtpasswdKey    = "htpasswd"
+	gitServiceName         = "zarf-gitea-http"
+)
+
+// IsGitServerDeployed returns true if the Zarf git server is running in the cluster.
+func (c *Cluster) IsGitServerDeployed() bool {
+	_, err := c.Kube.GetService(ZarfNamespace, gitServiceName)
+	return err == nil
+}
+
+// UpdateRegistryHtpasswd writes the push and pull users of the given registry info to the Zarf registry htpasswd
+// secret and restarts the registry so it picks them up.
+func (c *Cluster) UpdateRegistryHtpasswd(regInfo types.RegistryInfo) error {
+	message.Debugf("cluster.UpdateRegistryHtpasswd()")
+
+	pushUser, err := utils.GetHtpasswdString(regInfo.PushUsername, regInfo.PushPassword)
+	if err != nil {
+		return fmt.Errorf("error generating htpasswd string: %w", err)
+	}
+
+	pullUser, err := utils.GetHtpasswdString(regInfo.PullUsername, regInfo.PullPassword)
+	if err != nil {
+		return fmt.Errorf("error generating htpasswd string: %w", err)
+	}
+
+	secret, err := c.Kube.GetSecret(ZarfNamespace, registrySecretName)
+	if err != nil {
+		return fmt.Errorf("unable to get the Zarf registry secret: %w", err)
+	}
+
+	// Update the secret in place so it stays owned by the registry chart
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[registryHtpasswdKey] = []byte(strings.Join([]string{pushUser, pullUser}, "\n"))
+	if _, err := c.Kube.UpdateSecret(secret); err != nil {
+		return fmt.Errorf("unable to update the Zarf registry secret: %w", err)
+	}
+
+	if err := c.Kube.RestartDeployment(ZarfNamespace, registryDeploymentName); err != nil {
+		return fmt.Errorf("unable to restart the Zarf registry: %w", err)
+	}
+
+	if err := c.Kube.WaitForDeploymentRollout(ZarfNamespace, registryDeploymentName, 5*time.Minute); err != nil {
+		return fmt.Errorf("unable to restart the Zarf registry: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateZarfManagedSecrets refreshes the private-registry and private-git-server secrets in every namespace Zarf has
+// created them in so they match the credentials in the given state.
+func (c *Cluster) UpdateZarfManagedSecrets(state types.ZarfState) error {
+	message.Debugf("cluster.UpdateZarfManagedSecrets()")
+
+	registrySecrets, err := c.Kube.GetSecretsWithName(config.ZarfImagePullSecretName)
+	if err != nil {
+		return fmt.Errorf("unable to list the Zarf registry secrets: %w", err)
+	}
+
+	for _, currentSecret := range registrySecrets.Items {
+		namespace := currentSecret.Namespace
+
+		// Only touch the secrets Zarf created
+		if currentSecret.Labels[config.ZarfManagedByLabel] != "zarf" {
+			continue
+		}
+
+		validSecret, err := c.GenerateRegistryPullCreds(namespace, config.ZarfImagePullSecretName)
+		if err != nil {
+			return fmt.Errorf("unable to generate the registry pull secret for namespace %s: %w", namespace, err)
+		}
+
+		if err := c.Kube.ReplaceSecret(validSecret); err != nil {
+			return fmt.Errorf("unable to update the registry secret for namespace %s: %w", namespace, err)
+		}
+
+		gitServerSecret := c.Kube.GenerateSecret(namespace, config.ZarfGitServerSecretName, corev1.SecretTypeOpaque)
+		gitServerSecret.StringData = map[string]string{
+			"username": state.GitServer.PullUsername,
+			"password": state.GitServer.PullPassword,
+		}
+
+		if err := c.Kube.ReplaceSecret(gitServerSecret); err != nil {
+			return fmt.Errorf("unable to update the git server secret for namespace %s: %w", namespace, err)
+		}
+	}
+
+	return nil
+}
@@ -10,7 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/k8s"
@@ -20,21 +20,42 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-// HandleDataInjection waits for the target pod(s) to come up and inject the data into them
-// todo:  this currently requires kubectl but we should have enough k8s work to make this native now.
-func (c *Cluster) HandleDataInjection(wg *sync.WaitGroup, data types.ZarfDataInjection, componentPath types.ComponentPaths) {
-	message.Debugf("packager.handleDataInjections(%#v, %#v, %#v)", wg, data, componentPath)
-	defer wg.Done()
+const (
+	// Each attempt waits up to 90 seconds for the target pods, as some pods can take a long time to come up
+	defaultDataInjectionAttempts = 10
+	// The timeout in seconds covers all attempts of a data injection
+	defaultDataInjectionTimeout = 900
+	dataInjectionDelay          = 5 * time.Second
+)
+
+// HandleDataInjection waits for the target pod(s) to come up and injects the data into them, retrying until the
+// injection's retry limit or timeout is reached or the context is canceled.
+func (c *Cluster) HandleDataInjection(ctx context.Context, data types.ZarfDataInjection, componentPath types.ComponentPaths) error {
+	message.Debugf("cluster.HandleDataInjection(%#v, %#v)", data, componentPath)
+
+	attempts := data.MaxRetries
+	if attempts < 1 {
+		attempts = defaultDataInjectionAttempts
+	}
+
+	timeoutSeconds := data.TimeoutSeconds
+	if timeoutSeconds < 1 {
+		timeoutSeconds = defaultDataInjectionTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
 
 	injectionCompletionMarker := filepath.Join(componentPath.DataInjections, config.GetDataInjectionMarker())
 	if err := utils.WriteFile(injectionCompletionMarker, []byte("🦄")); err != nil {
-		message.Errorf(err, "Unable to create the data injection completion marker")
-		return
+		return fmt.Errorf("unable to create the data injection completion marker: %w", err)
 	}
 
-	tarCompressFlag := ""
-	if data.Compress {
-		tarCompressFlag = "z"
+	source := filepath.Join(componentPath.DataInjections, filepath.Base(data.Target.Path))
+
+	size, err := utils.GetDirSize(source)
+	if err != nil {
+		return fmt.Errorf("unable to read the data injection source %s: %w", source, err)
 	}
 
 	// Pod filter to ensure we only use the current deployment's pods
@@ -43,81 +64,81 @@ func (c *Cluster) HandleDataInjection(wg *sync.WaitGroup, data types.ZarfDataInj
 		return strings.Contains(message.JSONValue(pod), config.GetDataInjectionMarker())
 	}
 
-iterator:
-	// The eternal loop because some data injections can take a very long time
-	for {
-		message.Debugf("Attempting to inject data into %s", data.Target)
-		source := filepath.Join(componentPath.DataInjections, filepath.Base(data.Target.Path))
+	target := k8s.PodLookup{
+		Namespace: data.Target.Namespace,
+		Selector:  data.Target.Selector,
+		Container: data.Target.Container,
+	}
 
-		target := k8s.PodLookup{
-			Namespace: data.Target.Namespace,
-			Selector:  data.Target.Selector,
-			Container: data.Target.Container,
-		}
+	injectData := func() error {
+		message.Debugf("Attempting to inject data into %s", data.Target)
 
 		// Wait until the pod we are injecting data into becomes available
-		pods := c.Kube.WaitForPodsAndContainers(target, podFilterByInitContainer)
+		pods := c.Kube.WaitForPodsAndContainersWithContext(ctx, target, podFilterByInitContainer)
 		if len(pods) < 1 {
-			continue
+			return fmt.Errorf("no pods matching %s with container %s are running in namespace %s", data.Target.Selector, data.Target.Container, data.Target.Namespace)
 		}
 
 		// Inject into all the pods
 		for _, pod := range pods {
-			kubectlExec := fmt.Sprintf("kubectl exec -i -n %s %s -c %s ", data.Target.Namespace, pod, data.Target.Container)
-			tarExec := fmt.Sprintf("tar c%s", tarCompressFlag)
-			untarExec := fmt.Sprintf("tar x%svf - -C %s", tarCompressFlag, data.Target.Path)
+			progressBar := message.NewProgressBar(size, "Injecting %s into pod %s", data.Source, pod)
 
-			// Must create the target directory before trying to change to it for untar
-			mkdirExec := fmt.Sprintf("%s -- mkdir -p %s", kubectlExec, data.Target.Path)
-			_, _, err := utils.ExecCommandWithContext(context.TODO(), true, "sh", "-c", mkdirExec)
+			err := c.Kube.CopyToPod(ctx, data.Target.Namespace, pod, data.Target.Container, source, data.Target.Path, data.Compress, progressBar)
 			if err != nil {
-				message.Warnf("Unable to create the data injection target directory %s in pod %s", data.Target.Path, pod)
-				continue iterator
+				progressBar.Stop()
+				message.Warnf("Error copying data into the pod %s: %s", pod, err.Error())
+				return err
 			}
 
-			cpPodExec := fmt.Sprintf("%s -C %s . | %s -- %s",
-				tarExec,
-				source,
-				kubectlExec,
-				untarExec,
-			)
-
-			// Do the actual data injection
-			_, _, err = utils.ExecCommandWithContext(context.TODO(), true, "sh", "-c", cpPodExec)
-			if err != nil {
-				message.Warnf("Error copying data into the pod %#v: %#v\n", pod, err)
-				continue iterator
-			}
 			// Leave a marker in the target container for pods to track the sync action
-			cpPodExec = fmt.Sprintf("%s -C %s %s | %s -- %s",
-				tarExec,
-				componentPath.DataInjections,
-				config.GetDataInjectionMarker(),
-				kubectlExec,
-				untarExec,
-			)
-			_, _, err = utils.ExecCommandWithContext(context.TODO(), true, "sh", "-c", cpPodExec)
+			err = c.Kube.CopyToPod(ctx, data.Target.Namespace, pod, data.Target.Container, injectionCompletionMarker, data.Target.Path, data.Compress, nil)
 			if err != nil {
-				message.Warnf("Error saving the zarf sync completion file after injection into pod %#v\n", pod)
-				continue iterator
+				progressBar.Stop()
+				message.Warnf("Error saving the zarf sync completion file after injection into pod %s: %s", pod, err.Error())
+				return err
 			}
+
+			progressBar.Success("Injected %s into pod %s", data.Source, pod)
 		}
 
-		// Do not look for a specific container after injection in case they are running an init container
-		podOnlyTarget := k8s.PodLookup{
-			Namespace: data.Target.Namespace,
-			Selector:  data.Target.Selector,
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = injectData()
+		if err == nil {
+			break
 		}
 
-		// Block one final time to make sure at least one pod has come up and injected the data
-		// Using only the pod as the final selector because we don't know what the container name will be
-		// Still using the init container filter to make sure we have the right running pod
-		_ = c.Kube.WaitForPodsAndContainers(podOnlyTarget, podFilterByInitContainer)
+		if attempt >= attempts {
+			return fmt.Errorf("unable to inject %s into %s after %d attempts: %w", data.Source, data.Target.Path, attempts, err)
+		}
 
-		// Cleanup now to reduce disk pressure
-		_ = os.RemoveAll(source)
+		message.Debugf("Data injection attempt %d of %d failed: %s", attempt, attempts, err.Error())
 
-		// Return to stop the loop
-		return
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("unable to inject %s into %s within %d seconds: %w", data.Source, data.Target.Path, timeoutSeconds, err)
+			}
+			return fmt.Errorf("data injection of %s into %s was canceled: %w", data.Source, data.Target.Path, err)
+		case <-time.After(dataInjectionDelay):
+		}
+	}
+
+	// Do not look for a specific container after injection in case they are running an init container
+	podOnlyTarget := k8s.PodLookup{
+		Namespace: data.Target.Namespace,
+		Selector:  data.Target.Selector,
 	}
+
+	// Block one final time to make sure at least one pod has come up and injected the data
+	// Using only the pod as the final selector because we don't know what the container name will be
+	// Still using the init container filter to make sure we have the right running pod
+	_ = c.Kube.WaitForPodsAndContainersWithContext(ctx, podOnlyTarget, podFilterByInitContainer)
+
+	// Cleanup now to reduce disk pressure
+	_ = os.RemoveAll(source)
+
+	return nil
 }
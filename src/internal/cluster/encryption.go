@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/envelope"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ZarfStateEncryptedDataKey is the key of the zarf-state secret that holds the encrypted sensitive fields
+	ZarfStateEncryptedDataKey = "state.enc"
+	// ZarfStateCredentialsDataKey is the key of the zarf-state secret that holds the sensitive fields when the rest
+	// of the state is stored in the ZarfState resource
+	ZarfStateCredentialsDataKey = "credentials"
+
+	stateKeySecretName = "zarf-state-key"
+	stateKeyDataKey    = "key"
+)
+
+// stateSecrets contains the sensitive fields of the Zarf state that are encrypted at rest.
+type stateSecrets struct {
+	RegistryPushPassword string `json:"registryPushPassword"`
+	RegistryPullPassword string `json:"registryPullPassword"`
+	RegistrySecret       string `json:"registrySecret"`
+	GitPushPassword      string `json:"gitPushPassword"`
+	GitPullPassword      string `json:"gitPullPassword"`
+	LoggingSecret        string `json:"loggingSecret"`
+	AgentKey             []byte `json:"agentKey"`
+}
+
+// extractStateSecrets removes the sensitive fields from the state and returns them.
+func extractStateSecrets(state *types.ZarfState) stateSecrets {
+	secrets := stateSecrets{
+		RegistryPushPassword: state.RegistryInfo.PushPassword,
+		RegistryPullPassword: state.RegistryInfo.PullPassword,
+		RegistrySecret:       state.RegistryInfo.Secret,
+		GitPushPassword:      state.GitServer.PushPassword,
+		GitPullPassword:      state.GitServer.PullPassword,
+		LoggingSecret:        state.LoggingSecret,
+		AgentKey:             state.AgentTLS.Key,
+	}
+
+	state.RegistryInfo.PushPassword = ""
+	state.RegistryInfo.PullPassword = ""
+	state.RegistryInfo.Secret = ""
+	state.GitServer.PushPassword = ""
+	state.GitServer.PullPassword = ""
+	state.LoggingSecret = ""
+	state.AgentTLS.Key = nil
+
+	return secrets
+}
+
+// apply puts the sensitive fields back into the state.
+func (s stateSecrets) apply(state *types.ZarfState) {
+	state.RegistryInfo.PushPassword = s.RegistryPushPassword
+	state.RegistryInfo.PullPassword = s.RegistryPullPassword
+	state.RegistryInfo.Secret = s.RegistrySecret
+	state.GitServer.PushPassword = s.GitPushPassword
+	state.GitServer.PullPassword = s.GitPullPassword
+	state.LoggingSecret = s.LoggingSecret
+	state.AgentTLS.Key = s.AgentKey
+}
+
+// SetStateEncryption turns on the encryption of the Zarf state with the key supplied to init, either a key file or the
+// ZARF_STATE_ENCRYPTION_KEY environment variable. A state that is already encrypted keeps its
+// settings unless a new key is supplied.
+func (c *Cluster) SetStateEncryption(state *types.ZarfState, initOptions types.ZarfInitOptions) error {
+	message.Debugf("cluster.SetStateEncryption()")
+
+	var keyData []byte
+
+	switch {
+	case initOptions.StateEncryptionKeyPath != "":
+		data, err := os.ReadFile(initOptions.StateEncryptionKeyPath)
+		if err != nil {
+			return fmt.Errorf("unable to read the state encryption key: %w", err)
+		}
+		keyData = data
+
+	case os.Getenv(config.ZarfStateEncryptionKeyEnv) != "" && state.Encryption.Provider == "":
+		keyData = []byte(os.Getenv(config.ZarfStateEncryptionKeyEnv))
+
+	default:
+		return nil
+	}
+
+	key, err := envelope.ParseKey(keyData)
+	if err != nil {
+		return fmt.Errorf("invalid state encryption key: %w", err)
+	}
+
+	// The Zarf Agent and later commands read the key from the cluster to decrypt the state
+	secret := c.Kube.GenerateSecret(ZarfNamespace, stateKeySecretName, corev1.SecretTypeOpaque)
+	secret.Data[stateKeyDataKey] = key
+	if err := c.Kube.ReplaceSecret(secret); err != nil {
+		return fmt.Errorf("unable to save the state encryption key: %w", err)
+	}
+
+	state.Encryption = types.StateEncryption{Provider: config.ZarfStateEncryptionLocal}
+	return nil
+}
+
+// DecodeZarfState reads the Zarf state from the zarf-state secret (and the ZarfState resource if the secret only holds
+// the credentials) and decrypts its sensitive fields if they are encrypted.
+func (c *Cluster) DecodeZarfState(secret *corev1.Secret) (types.ZarfState, error) {
+	state := types.ZarfState{}
+
+	if data, ok := secret.Data[ZarfStateDataKey]; ok {
+		if err := json.Unmarshal(data, &state); err != nil {
+			return state, fmt.Errorf("unable to parse the zarf state: %w", err)
+		}
+	} else {
+		var err error
+		if state, err = c.getZarfStateObject(); err != nil {
+			return state, fmt.Errorf("unable to get the ZarfState resource: %w", err)
+		}
+	}
+
+	sealed, encrypted := secret.Data[ZarfStateEncryptedDataKey]
+	if !encrypted {
+		if credentials, ok := secret.Data[ZarfStateCredentialsDataKey]; ok {
+			var secrets stateSecrets
+			if err := json.Unmarshal(credentials, &secrets); err != nil {
+				return state, fmt.Errorf("unable to parse the zarf state credentials: %w", err)
+			}
+			secrets.apply(&state)
+		}
+		return state, nil
+	}
+
+	var env envelope.Envelope
+	if err := json.Unmarshal(sealed, &env); err != nil {
+		return state, fmt.Errorf("unable to parse the encrypted zarf state: %w", err)
+	}
+
+	provider, err := c.getStateKeyProvider(state.Encryption)
+	if err != nil {
+		return state, err
+	}
+
+	data, err := envelope.Open(provider, env)
+	if err != nil {
+		return state, fmt.Errorf("unable to decrypt the zarf state: %w", err)
+	}
+
+	var secrets stateSecrets
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return state, fmt.Errorf("unable to parse the decrypted zarf state: %w", err)
+	}
+	secrets.apply(&state)
+
+	return state, nil
+}
+
+// encodeZarfState returns the data of the zarf-state secret, with the sensitive fields encrypted if the state has
+// encryption turned on. With credentialsOnly the rest of the state is left out of the secret.
+func (c *Cluster) encodeZarfState(state types.ZarfState, credentialsOnly bool) (map[string][]byte, error) {
+	dataWrapper := make(map[string][]byte)
+
+	if state.Encryption.Provider == "" && credentialsOnly {
+		credentials, err := json.Marshal(extractStateSecrets(&state))
+		if err != nil {
+			return nil, fmt.Errorf("unable to json-encode the zarf state credentials: %w", err)
+		}
+		dataWrapper[ZarfStateCredentialsDataKey] = credentials
+		return dataWrapper, nil
+	}
+
+	if state.Encryption.Provider != "" {
+		provider, err := c.getStateKeyProvider(state.Encryption)
+		if err != nil {
+			return nil, err
+		}
+
+		secretsData, err := json.Marshal(extractStateSecrets(&state))
+		if err != nil {
+			return nil, fmt.Errorf("unable to json-encode the zarf state secrets: %w", err)
+		}
+
+		env, err := envelope.Seal(provider, secretsData)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encrypt the zarf state: %w", err)
+		}
+
+		if dataWrapper[ZarfStateEncryptedDataKey], err = json.Marshal(env); err != nil {
+			return nil, fmt.Errorf("unable to json-encode the encrypted zarf state: %w", err)
+		}
+	}
+
+	if credentialsOnly {
+		return dataWrapper, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("unable to json-encode the zarf state: %w", err)
+	}
+	dataWrapper[ZarfStateDataKey] = data
+
+	return dataWrapper, nil
+}
+
+// getStateKeyProvider returns the provider of the key that encrypts the Zarf state.
+func (c *Cluster) getStateKeyProvider(encryption types.StateEncryption) (envelope.KeyProvider, error) {
+	switch encryption.Provider {
+	case config.ZarfStateEncryptionLocal:
+		// Prefer a key from the environment so the key secret is not required outside of the cluster
+		if keyData := os.Getenv(config.ZarfStateEncryptionKeyEnv); keyData != "" {
+			key, err := envelope.ParseKey([]byte(keyData))
+			if err != nil {
+				return nil, fmt.Errorf("invalid state encryption key: %w", err)
+			}
+			return envelope.NewLocalKeyProvider(key)
+		}
+
+		secret, err := c.Kube.GetSecret(ZarfNamespace, stateKeySecretName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the state encryption key: %w", err)
+		}
+		return envelope.NewLocalKeyProvider(secret.Data[stateKeyDataKey])
+
+	default:
+		return nil, fmt.Errorf("unknown state encryption provider %q", encryption.Provider)
+	}
+}
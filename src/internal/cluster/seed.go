@@ -36,7 +36,11 @@ func (c *Cluster) InitZarfState(tempPath types.TempPaths, initOptions types.Zarf
 	// Attempt to load an existing state prior to init
 	// NOTE: We are ignoring the error here because we don't really expect a state to exist yet
 	spinner.Updatef("Checking cluster for existing Zarf deployment")
-	state, _ := c.LoadZarfState()
+	state, err := c.LoadZarfState()
+	if err != nil && state.Distro != "" {
+		// The state exists but could not be decrypted, don't overwrite its credentials
+		return fmt.Errorf("unable to load the existing Zarf state: %w", err)
+	}
 
 	// If the distro isn't populated in the state, assume this is a new cluster
 	if state.Distro == "" {
@@ -107,9 +111,24 @@ func (c *Cluster) InitZarfState(tempPath types.TempPaths, initOptions types.Zarf
 		state.StorageClass = initOptions.StorageClass
 	}
 
+	// The image policy is set on every init so it can be turned on or off later
+	state.AgentImagePolicy = initOptions.AgentImagePolicy
+
 	state.GitServer = c.fillInEmptyGitServerValues(initOptions.GitServer)
 	state.RegistryInfo = c.fillInEmptyContainerRegistryValues(initOptions.RegistryInfo)
 
+	if err := c.SetStateEncryption(&state, initOptions); err != nil {
+		return err
+	}
+
+	// Once installed the Zarf CRDs are kept, so the state stays in the ZarfState resource on later inits
+	if initOptions.StateCRDs {
+		spinner.Updatef("Installing the Zarf custom resource definitions")
+		if err := c.InstallZarfCRDs(); err != nil {
+			return fmt.Errorf("unable to install the Zarf custom resource definitions: %w", err)
+		}
+	}
+
 	spinner.Success()
 
 	// Save the state back to K8s
@@ -5,7 +5,6 @@
 package cluster
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/defenseunicorns/zarf/src/config"
@@ -27,16 +26,16 @@ const (
 func (c *Cluster) LoadZarfState() (types.ZarfState, error) {
 	message.Debug("k8s.LoadZarfState()")
 
-	// The empty state that we will try to fill
-	state := types.ZarfState{}
-
 	// Set up the API connection
 	secret, err := c.Kube.GetSecret(ZarfNamespace, ZarfStateSecretName)
 	if err != nil {
-		return state, err
+		return types.ZarfState{}, err
 	}
 
-	_ = json.Unmarshal(secret.Data[ZarfStateDataKey], &state)
+	state, err := c.DecodeZarfState(secret)
+	if err != nil {
+		return state, err
+	}
 
 	message.Debugf("ZarfState = %s", message.JSONValue(state))
 
@@ -48,15 +47,19 @@ func (c *Cluster) SaveZarfState(state types.ZarfState) error {
 	message.Debugf("k8s.SaveZarfState()")
 	message.Debug(message.JSONValue(state))
 
-	// Convert the data back to JSON
-	data, err := json.Marshal(state)
-	if err != nil {
-		return fmt.Errorf("unable to json-encode the zarf state")
+	// With the Zarf CRDs the ZarfState resource holds the state and the secret only holds its credentials
+	useCRDs := c.UsesZarfCRDs()
+	if useCRDs {
+		if err := c.saveZarfStateObject(state); err != nil {
+			return fmt.Errorf("unable to save the ZarfState resource: %w", err)
+		}
 	}
 
-	// Set up the data wrapper
-	dataWrapper := make(map[string][]byte)
-	dataWrapper[ZarfStateDataKey] = data
+	// Convert the data back to JSON, encrypting the sensitive fields if needed
+	dataWrapper, err := c.encodeZarfState(state, useCRDs)
+	if err != nil {
+		return err
+	}
 
 	// The secret object
 	secret := &corev1.Secret{
@@ -68,11 +68,23 @@ type Tunnel struct {
 
 // PrintConnectTable will print a table of all Zarf connect matches found in the cluster.
 func (c *Cluster) PrintConnectTable() error {
-	list, err := c.Kube.GetServicesByLabelExists(v1.NamespaceAll, config.ZarfConnectLabelName)
+	connections, err := c.GetConnectStrings()
 	if err != nil {
 		return err
 	}
 
+	message.PrintConnectStringTable(connections)
+
+	return nil
+}
+
+// GetConnectStrings returns all Zarf connect matches found in the cluster.
+func (c *Cluster) GetConnectStrings() (types.ConnectStrings, error) {
+	list, err := c.Kube.GetServicesByLabelExists(v1.NamespaceAll, config.ZarfConnectLabelName)
+	if err != nil {
+		return nil, err
+	}
+
 	connections := make(types.ConnectStrings)
 
 	for _, svc := range list.Items {
@@ -85,9 +97,21 @@ func (c *Cluster) PrintConnectTable() error {
 		}
 	}
 
-	message.PrintConnectStringTable(connections)
+	return connections, nil
+}
 
-	return nil
+// InClusterRegistryAddress returns the address pods in the cluster reach the Zarf registry at, which is the registry
+// service rather than the node port used by the kubelet, and whether the registry is served over plain HTTP.
+func InClusterRegistryAddress(regInfo types.RegistryInfo) (string, bool) {
+	address := regInfo.Address
+	if regInfo.InternalRegistry {
+		address = config.ZarfInClusterContainerRegistryURL
+	}
+
+	plainHTTP := strings.HasPrefix(address, "http://")
+	address = strings.TrimPrefix(strings.TrimPrefix(address, "http://"), "https://")
+
+	return address, plainHTTP
 }
 
 // IsServiceURL will check if the provided string is a valid serviceURL based on if it properly matches a validating regexp.
@@ -245,7 +269,7 @@ func (tunnel *Tunnel) Connect(target string, blocking bool) error {
 		defer tunnel.Close()
 
 		// Keep this open until an interrupt signal is received.
-		c := make(chan os.Signal)
+		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		go func() {
 			<-c
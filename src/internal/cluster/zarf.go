@@ -7,19 +7,54 @@ package cluster
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/types"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// The number of deployment generations kept for each package
+	packageHistoryLimit = 10
+	// The label of the secrets that hold the deployment history of a package
+	packageHistoryLabel = "package-deploy-history"
+	// The label of the secret that holds the package being deployed
+	packagePendingLabel = "package-deploy-pending"
+	// The configmap that lists the images of the deployed packages for the Zarf Agent, so the agent does not need
+	// access to the package secrets
+	packageImagesConfigMapName = "zarf-package-images"
+	packageImagesDataKey       = "images"
+)
+
 // GetDeployedZarfPackages gets metadata information about packages that have been deployed to the cluster.
-// We determine what packages have been deployed to the cluster by looking for specific secrets in the Zarf namespace.
+// We determine what packages have been deployed to the cluster by looking for specific secrets (or ZarfDeployedPackage
+// resources) in the Zarf namespace.
 func (c *Cluster) GetDeployedZarfPackages() ([]types.DeployedPackage, error) {
 	var deployedPackages = []types.DeployedPackage{}
 
+	if c.UsesZarfCRDs() {
+		objects, err := c.getPackageObjects()
+		if err != nil {
+			return deployedPackages, err
+		}
+
+		for _, object := range objects {
+			// Packages that are being deployed for the first time are not deployed yet
+			if object.Spec.Name != "" {
+				deployedPackages = append(deployedPackages, object.Spec)
+			}
+		}
+
+		return deployedPackages, nil
+	}
+
 	// Get the secrets that describe the deployed packages
 	namespace := "zarf"
 	labelSelector := "package-deploy-info"
@@ -82,21 +117,391 @@ func (c *Cluster) StripZarfLabelsAndSecretsFromNamespaces() {
 	spinner.Success()
 }
 
-// RecordPackageDeployment saves metadata about a package that has been deployed to the cluster.
-func (c *Cluster) RecordPackageDeployment(pkg types.ZarfPackage, components []types.DeployedComponent) {
-	// Generate a secret that describes the package that is being deployed
+// GetDeployedPackage gets the metadata information about a package that has been deployed to the cluster.
+func (c *Cluster) GetDeployedPackage(packageName string) (types.DeployedPackage, error) {
+	var deployedPackage types.DeployedPackage
+
+	if c.UsesZarfCRDs() {
+		object, err := c.getPackageObject(packageName)
+		if err == nil && object.Spec.Name == "" {
+			err = deployedPackageNotFound(packageName)
+		}
+		return object.Spec, err
+	}
+
+	secret, err := c.Kube.GetSecret("zarf", config.ZarfPackagePrefix+packageName)
+	if err != nil {
+		return deployedPackage, err
+	}
+
+	err = json.Unmarshal(secret.Data["data"], &deployedPackage)
+	return deployedPackage, err
+}
+
+// GetPackageDeploymentHistory gets the recorded deployment generations of a package, oldest first.
+func (c *Cluster) GetPackageDeploymentHistory(packageName string) ([]types.DeployedPackage, error) {
+	var history = []types.DeployedPackage{}
+
+	if c.UsesZarfCRDs() {
+		object, err := c.getPackageObject(packageName)
+		if err != nil {
+			return history, err
+		}
+		history = append(history, object.getHistory()...)
+	} else {
+		secrets, err := c.Kube.GetSecretsWithLabel("zarf", packageHistoryLabel+"="+packageName)
+		if err != nil {
+			return history, err
+		}
+
+		for _, secret := range secrets.Items {
+			var deployedPackage types.DeployedPackage
+			if err := json.Unmarshal(secret.Data["data"], &deployedPackage); err != nil {
+				return history, fmt.Errorf("unable to read the deployment history secret %s: %w", secret.Name, err)
+			}
+			history = append(history, deployedPackage)
+		}
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Generation < history[j].Generation
+	})
+
+	return history, nil
+}
+
+// RecordPackageDeployment saves metadata about a package that has been deployed to the cluster as a new generation of
+// its deployment, keeping the last packageHistoryLimit generations for rollbacks.
+func (c *Cluster) RecordPackageDeployment(pkg types.ZarfPackage, components []types.DeployedComponent) (err error) {
+	defer c.syncPackageImages(&err)
+
 	packageName := pkg.Metadata.Name
-	deployedPackageSecret := c.Kube.GenerateSecret("zarf", config.ZarfPackagePrefix+packageName, corev1.SecretTypeOpaque)
-	deployedPackageSecret.Labels["package-deploy-info"] = packageName
+	useCRDs := c.UsesZarfCRDs()
+
+	generation := 1
+	if previous, err := c.GetDeployedPackage(packageName); err == nil {
+		// Packages deployed before generations were recorded have no history, so keep them as the first generation
+		if previous.Generation == 0 {
+			previous.Generation = generation
+			if !useCRDs {
+				if err := c.recordPackageGeneration(previous); err != nil {
+					return err
+				}
+			}
+		}
+		generation = previous.Generation + 1
+	}
 
-	stateData, _ := json.Marshal(types.DeployedPackage{
+	deployedPackage := types.DeployedPackage{
 		Name:               packageName,
 		CLIVersion:         config.CLIVersion,
 		Data:               pkg,
+		Generation:         generation,
 		DeployedComponents: components,
-	})
+	}
 
-	deployedPackageSecret.Data = map[string][]byte{"data": stateData}
+	if useCRDs {
+		return c.recordPackageObject(deployedPackage)
+	}
+
+	if err := c.savePackageSecret(deployedPackage); err != nil {
+		return err
+	}
+
+	if err := c.recordPackageGeneration(deployedPackage); err != nil {
+		return err
+	}
+
+	return c.prunePackageDeploymentHistory(packageName, generation-packageHistoryLimit)
+}
+
+// RecordPendingPackageDeployment saves the package being deployed with the components selected for deployment, so the
+// Zarf Agent image policy allows their images before the deployment has been recorded.
+func (c *Cluster) RecordPendingPackageDeployment(pkg types.ZarfPackage, components []types.ZarfComponent) (err error) {
+	defer c.syncPackageImages(&err)
+
+	packageName := pkg.Metadata.Name
+
+	deployedPackage := types.DeployedPackage{
+		Name:       packageName,
+		CLIVersion: config.CLIVersion,
+		Data:       pkg,
+	}
+	for _, component := range components {
+		deployedPackage.DeployedComponents = append(deployedPackage.DeployedComponents, types.DeployedComponent{Name: component.Name})
+	}
+
+	if c.UsesZarfCRDs() {
+		return c.recordPendingPackageObject(deployedPackage)
+	}
+
+	// Package names cannot contain dots, so this never collides with the secret of another package
+	pendingSecret := c.Kube.GenerateSecret("zarf", config.ZarfPackagePrefix+packageName+".pending", corev1.SecretTypeOpaque)
+	pendingSecret.Labels[packagePendingLabel] = packageName
+
+	data, err := json.Marshal(deployedPackage)
+	if err != nil {
+		return err
+	}
+
+	pendingSecret.Data = map[string][]byte{"data": data}
+
+	if err := c.Kube.ReplaceSecret(pendingSecret); err != nil {
+		return fmt.Errorf("unable to save the pending package deployment: %w", err)
+	}
+
+	return nil
+}
+
+// ClearPendingPackageDeployment removes the record of a package being deployed.
+func (c *Cluster) ClearPendingPackageDeployment(packageName string) (err error) {
+	defer c.syncPackageImages(&err)
+
+	if c.UsesZarfCRDs() {
+		return c.clearPendingPackageObject(packageName)
+	}
+
+	pendingSecret := c.Kube.GenerateSecret("zarf", config.ZarfPackagePrefix+packageName+".pending", corev1.SecretTypeOpaque)
+	return c.Kube.DeleteSecret(pendingSecret)
+}
+
+// GetPackageImages returns the images of the deployed components of all the packages deployed to or being deployed to
+// the cluster as they were last saved for the Zarf Agent.
+func (c *Cluster) GetPackageImages() ([]string, error) {
+	configMap, err := c.Kube.Clientset.CoreV1().ConfigMaps(ZarfNamespace).Get(context.TODO(), packageImagesConfigMapName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(configMap.BinaryData[packageImagesDataKey])), nil
+}
+
+// syncPackageImages saves the images of the deployed packages for the Zarf Agent after a package record changed
+// successfully.
+func (c *Cluster) syncPackageImages(err *error) {
+	if *err != nil {
+		return
+	}
+
+	images, collectErr := c.collectPackageImages()
+	if collectErr != nil {
+		*err = fmt.Errorf("unable to read the images of the deployed packages: %w", collectErr)
+		return
+	}
+
+	sort.Strings(images)
+	data := map[string][]byte{packageImagesDataKey: []byte(strings.Join(images, "\n"))}
+	if _, applyErr := c.Kube.ApplyConfigmap(ZarfNamespace, packageImagesConfigMapName, data); applyErr != nil {
+		*err = fmt.Errorf("unable to save the images of the deployed packages: %w", applyErr)
+	}
+}
+
+// collectPackageImages returns the images of the deployed components of all the packages deployed to or being deployed
+// to the cluster, as they are referenced in the package. The images left out of differential packages are included as
+// well, since they are still in use.
+func (c *Cluster) collectPackageImages() ([]string, error) {
+	var images []string
+	var deployedPackages []types.DeployedPackage
+
+	if c.UsesZarfCRDs() {
+		objects, err := c.getPackageObjects()
+		if err != nil {
+			return images, err
+		}
+
+		for _, object := range objects {
+			deployedPackages = append(deployedPackages, object.Spec)
+			if object.Status.Pending != nil {
+				deployedPackages = append(deployedPackages, *object.Status.Pending)
+			}
+		}
+	} else {
+		for _, label := range []string{"package-deploy-info", packagePendingLabel} {
+			secrets, err := c.Kube.GetSecretsWithLabel("zarf", label)
+			if err != nil {
+				return images, err
+			}
+
+			for _, secret := range secrets.Items {
+				var deployedPackage types.DeployedPackage
+				if err := json.Unmarshal(secret.Data["data"], &deployedPackage); err != nil {
+					return images, fmt.Errorf("unable to read the package secret %s: %w", secret.Name, err)
+				}
+				deployedPackages = append(deployedPackages, deployedPackage)
+			}
+		}
+	}
+
+	for _, deployedPackage := range deployedPackages {
+		deployedComponents := map[string]bool{}
+		for _, component := range deployedPackage.DeployedComponents {
+			deployedComponents[component.Name] = true
+		}
+
+		for _, component := range deployedPackage.Data.Components {
+			if deployedComponents[component.Name] {
+				images = append(images, component.Images...)
+			}
+		}
+
+		if len(deployedComponents) > 0 {
+			images = append(images, deployedPackage.Data.Build.DifferentialMissing...)
+		}
+	}
+
+	return images, nil
+}
+
+// UpdateDeployedPackage saves the record of a package after some of its components were removed.
+func (c *Cluster) UpdateDeployedPackage(deployedPackage types.DeployedPackage, removedComponents []string) (err error) {
+	defer c.syncPackageImages(&err)
+
+	if c.UsesZarfCRDs() {
+		return c.updatePackageObject(deployedPackage, removedComponents)
+	}
+
+	return c.savePackageSecret(deployedPackage)
+}
+
+// RecordFailedPackageDeployment merges the components of a failed deployment into the record of a package, marking the
+// failed component, so that `zarf package remove` can clean them up. A failed deployment is not a new generation to
+// roll back to, so the generation and history of the package are kept as they are.
+func (c *Cluster) RecordFailedPackageDeployment(pkg types.ZarfPackage, components []types.DeployedComponent) (err error) {
+	defer c.syncPackageImages(&err)
+
+	packageName := pkg.Metadata.Name
+
+	deployedPackage, err := c.GetDeployedPackage(packageName)
+	if errors.IsNotFound(err) {
+		// The first deployment of a package gets a generation without being added to the history
+		deployedPackage = types.DeployedPackage{Name: packageName, Generation: 1}
+	} else if err != nil {
+		return fmt.Errorf("unable to get the record of the package %s: %w", packageName, err)
+	}
+
+	deployedPackage.Data = pkg
+	deployedPackage.CLIVersion = config.CLIVersion
+	deployedPackage.DeployedComponents = mergeDeployedComponents(deployedPackage.DeployedComponents, components)
+
+	if c.UsesZarfCRDs() {
+		return c.recordFailedPackageObject(deployedPackage)
+	}
+
+	return c.savePackageSecret(deployedPackage)
+}
+
+// mergeDeployedComponents replaces the recorded components that were deployed again and appends the new ones.
+func mergeDeployedComponents(recorded []types.DeployedComponent, deployed []types.DeployedComponent) []types.DeployedComponent {
+	merged := append([]types.DeployedComponent{}, recorded...)
+
+	for _, component := range deployed {
+		replaced := false
+		for idx := range merged {
+			if merged[idx].Name == component.Name {
+				merged[idx] = component
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, component)
+		}
+	}
+
+	return merged
+}
+
+// savePackageSecret saves the record of a package to its package secret.
+func (c *Cluster) savePackageSecret(deployedPackage types.DeployedPackage) error {
+	secretName := config.ZarfPackagePrefix + deployedPackage.Name
+	packageSecret := c.Kube.GenerateSecret("zarf", secretName, corev1.SecretTypeOpaque)
+	packageSecret.Labels["package-deploy-info"] = deployedPackage.Name
+
+	data, err := json.Marshal(deployedPackage)
+	if err != nil {
+		return err
+	}
+	packageSecret.Data = map[string][]byte{"data": data}
+
+	if err := c.Kube.ReplaceSecret(packageSecret); err != nil {
+		return fmt.Errorf("unable to save the %s package secret: %w", secretName, err)
+	}
+
+	return nil
+}
+
+// MarkPackageRemoving records that a package is being removed, this only shows on ZarfDeployedPackage resources.
+func (c *Cluster) MarkPackageRemoving(packageName string) error {
+	if c.UsesZarfCRDs() {
+		return c.markPackageObjectRemoving(packageName)
+	}
+
+	return nil
+}
+
+// DeleteDeployedPackage removes the record of a package and its deployment history.
+func (c *Cluster) DeleteDeployedPackage(packageName string) (err error) {
+	defer c.syncPackageImages(&err)
+
+	if c.UsesZarfCRDs() {
+		return c.Kube.DeleteCustomResource(zarfPackageResource, ZarfNamespace, packageName)
+	}
+
+	packageSecret := c.Kube.GenerateSecret("zarf", config.ZarfPackagePrefix+packageName, corev1.SecretTypeOpaque)
+	if err := c.Kube.DeleteSecret(packageSecret); err != nil {
+		return err
+	}
+
+	return c.DeletePackageDeploymentHistory(packageName)
+}
+
+// DeletePackageDeploymentHistory removes all recorded deployment generations of a package.
+func (c *Cluster) DeletePackageDeploymentHistory(packageName string) error {
+	return c.prunePackageDeploymentHistory(packageName, math.MaxInt)
+}
+
+// recordPackageGeneration saves a generation of a package deployment to its history.
+func (c *Cluster) recordPackageGeneration(deployedPackage types.DeployedPackage) error {
+	// Package names cannot contain dots, so this never collides with the secret of another package
+	secretName := fmt.Sprintf("%s%s.v%d", config.ZarfPackagePrefix, deployedPackage.Name, deployedPackage.Generation)
+	historySecret := c.Kube.GenerateSecret("zarf", secretName, corev1.SecretTypeOpaque)
+	historySecret.Labels[packageHistoryLabel] = deployedPackage.Name
+
+	data, err := json.Marshal(deployedPackage)
+	if err != nil {
+		return err
+	}
+
+	historySecret.Data = map[string][]byte{"data": data}
+
+	if err := c.Kube.ReplaceSecret(historySecret); err != nil {
+		return fmt.Errorf("unable to save generation %d of the package deployment: %w", deployedPackage.Generation, err)
+	}
+
+	return nil
+}
+
+// prunePackageDeploymentHistory removes the recorded deployment generations of a package up to and including the
+// given generation.
+func (c *Cluster) prunePackageDeploymentHistory(packageName string, generation int) error {
+	secrets, err := c.Kube.GetSecretsWithLabel("zarf", packageHistoryLabel+"="+packageName)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets.Items {
+		var deployedPackage types.DeployedPackage
+		if err := json.Unmarshal(secret.Data["data"], &deployedPackage); err == nil && deployedPackage.Generation > generation {
+			continue
+		}
+
+		if err := c.Kube.DeleteSecret(&secret); err != nil {
+			return fmt.Errorf("unable to remove the deployment history secret %s: %w", secret.Name, err)
+		}
+	}
 
-	c.Kube.ReplaceSecret(deployedPackageSecret)
+	return nil
 }
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeDeployedComponents(t *testing.T) {
+	recorded := []types.DeployedComponent{
+		{Name: "database", InstalledCharts: []types.InstalledChart{{Namespace: "db", ChartName: "postgres", Revision: 1}}},
+		{Name: "app", InstalledCharts: []types.InstalledChart{{Namespace: "app", ChartName: "app", Revision: 1}}},
+	}
+	deployed := []types.DeployedComponent{
+		{Name: "app", InstalledCharts: []types.InstalledChart{{Namespace: "app", ChartName: "app"}}, Status: types.ComponentStatusFailed},
+		{Name: "cache"},
+	}
+
+	merged := mergeDeployedComponents(recorded, deployed)
+	require.Equal(t, []types.DeployedComponent{recorded[0], deployed[0], deployed[1]}, merged)
+
+	// The recorded components are not changed in place
+	require.Equal(t, 1, recorded[1].InstalledCharts[0].Revision)
+	require.Empty(t, recorded[1].Status)
+
+	require.Equal(t, deployed, mergeDeployedComponents(nil, deployed))
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/defenseunicorns/zarf/src/internal/cluster"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
 )
 
 // CreateReadOnlyUser uses the Gitea API to create a non-admin Zarf user.
@@ -102,6 +103,62 @@ func (g *Git) CreateReadOnlyUser() error {
 	return err
 }
 
+// UpdateZarfGiteaUsers uses the Gitea API to set the passwords of the Zarf users to the ones in the git server info,
+// authenticating with the previous push password. If the read only user cannot be updated, the push user is changed
+// back to its previous password so that it still matches the Zarf state.
+func (g *Git) UpdateZarfGiteaUsers(oldPushPassword string) error {
+	message.Debugf("git.UpdateZarfGiteaUsers()")
+
+	if err := g.setPushUserPassword(oldPushPassword, g.Server.PushPassword); err != nil {
+		return err
+	}
+
+	// The push user now has the new password, use it to update the read only user
+	if err := g.CreateReadOnlyUser(); err != nil {
+		if revertErr := g.setPushUserPassword(g.Server.PushPassword, oldPushPassword); revertErr != nil {
+			return fmt.Errorf("%w (unable to change the push user password back: %s)", err, revertErr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RevertZarfGiteaUsers uses the Gitea API to set the passwords of the Zarf users back to the ones in the previous git
+// server info, for when the new passwords could not be saved to the Zarf state.
+func (g *Git) RevertZarfGiteaUsers(previous types.GitServerInfo) error {
+	message.Debugf("git.RevertZarfGiteaUsers()")
+
+	if err := g.setPushUserPassword(g.Server.PushPassword, previous.PushPassword); err != nil {
+		return err
+	}
+
+	return New(previous).CreateReadOnlyUser()
+}
+
+// setPushUserPassword uses the Gitea API to change the password of the push user, authenticating with its current one.
+func (g *Git) setPushUserPassword(currentPassword, newPassword string) error {
+	// Establish a git tunnel to reach the Gitea API
+	tunnel, err := cluster.NewZarfTunnel()
+	if err != nil {
+		return err
+	}
+	tunnel.Connect(cluster.ZarfGit, false)
+	defer tunnel.Close()
+
+	updateUserBody := map[string]interface{}{
+		"login_name":           g.Server.PushUsername,
+		"password":             newPassword,
+		"must_change_password": false,
+	}
+	updateUserData, _ := json.Marshal(updateUserBody)
+	updateUserEndpoint := fmt.Sprintf("http://%s/api/v1/admin/users/%s", tunnel.Endpoint(), g.Server.PushUsername)
+	updateUserRequest, _ := netHttp.NewRequest("PATCH", updateUserEndpoint, bytes.NewBuffer(updateUserData))
+	out, err := g.DoHTTPThings(updateUserRequest, g.Server.PushUsername, currentPassword)
+	message.Debugf("PATCH %s:\n%s", updateUserEndpoint, string(out))
+	return err
+}
+
 func (g *Git) addReadOnlyUserToRepo(tunnelURL, repo string) error {
 	message.Debugf("git.addReadOnlyUserToRepo()")
 
@@ -7,6 +7,7 @@ package helm
 import (
 	"crypto/sha1"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -22,8 +23,8 @@ import (
 	"helm.sh/helm/v3/pkg/storage/driver"
 )
 
-// InstallOrUpgradeChart performs a helm install of the given chart.
-func (h *Helm) InstallOrUpgradeChart() (types.ConnectStrings, string, error) {
+// InstallOrUpgradeChart performs a helm install of the given chart and returns the installed release and revision.
+func (h *Helm) InstallOrUpgradeChart() (types.ConnectStrings, types.InstalledChart, error) {
 	fromMessage := h.Chart.URL
 	if fromMessage == "" {
 		fromMessage = "Zarf-generated helm chart"
@@ -52,14 +53,17 @@ func (h *Helm) InstallOrUpgradeChart() (types.ConnectStrings, string, error) {
 	// Setup K8s connection
 	err := h.createActionConfig(h.Chart.Namespace, spinner)
 	if err != nil {
-		return nil, "", fmt.Errorf("unable to initialize the K8s client: %w", err)
+		return nil, types.InstalledChart{}, fmt.Errorf("unable to initialize the K8s client: %w", err)
 	}
 
 	postRender, err := h.newRenderer()
 	if err != nil {
-		return nil, "", fmt.Errorf("unable to create helm renderer: %w", err)
+		return nil, types.InstalledChart{}, fmt.Errorf("unable to create helm renderer: %w", err)
 	}
 
+	// Returned when the release may have been changed before failing, so the caller can undo it
+	failedChart := types.InstalledChart{Namespace: h.Chart.Namespace, ChartName: h.ReleaseName}
+
 	attempt := 0
 	for {
 		attempt++
@@ -69,20 +73,20 @@ func (h *Helm) InstallOrUpgradeChart() (types.ConnectStrings, string, error) {
 		histClient.Max = 1
 
 		if attempt > 4 {
-			// On total failure try to rollback or uninstall
-			if histClient.Version > 1 {
+			// On total failure try to rollback to the revision from before the upgrade or uninstall
+			if h.PreviousRevision > 0 {
 				spinner.Updatef("Performing chart rollback")
-				_ = h.rollbackChart(h.ReleaseName)
+				_ = h.rollbackChart(h.ReleaseName, h.PreviousRevision)
 			} else {
 				spinner.Updatef("Performing chart uninstall")
 				_, _ = h.uninstallChart(h.ReleaseName)
 			}
-			return nil, "", fmt.Errorf("unable to install/upgrade chart after 3 attempts")
+			return nil, failedChart, fmt.Errorf("unable to install/upgrade chart after 3 attempts")
 		}
 
 		spinner.Updatef("Checking for existing helm deployment")
 
-		_, histErr := histClient.Run(h.ReleaseName)
+		releases, histErr := histClient.Run(h.ReleaseName)
 
 		switch histErr {
 		case driver.ErrReleaseNotFound:
@@ -91,13 +95,23 @@ func (h *Helm) InstallOrUpgradeChart() (types.ConnectStrings, string, error) {
 			output, err = h.installChart(postRender)
 
 		case nil:
+			// Keep the revision from before the first attempt, the attempts below add revisions of their own
+			if attempt == 1 {
+				h.PreviousRevision = latestRevision(releases)
+			}
+
 			// Otherwise, there is a prior release so upgrade it
 			spinner.Updatef("Attempting chart upgrade")
 			output, err = h.upgradeChart(postRender)
 
 		default:
 			// 😭 things aren't working
-			return nil, "", fmt.Errorf("unable to verify the chart installation status: %w", histErr)
+			err = fmt.Errorf("unable to verify the chart installation status: %w", histErr)
+			if attempt == 1 {
+				// Nothing has been changed yet
+				return nil, types.InstalledChart{}, err
+			}
+			return nil, failedChart, err
 		}
 
 		if err != nil {
@@ -112,8 +126,14 @@ func (h *Helm) InstallOrUpgradeChart() (types.ConnectStrings, string, error) {
 
 	}
 
+	installedChart := types.InstalledChart{
+		Namespace: h.Chart.Namespace,
+		ChartName: h.ReleaseName,
+		Revision:  output.Version,
+	}
+
 	// return any collected connect strings for zarf connect
-	return postRender.connectStrings, h.ReleaseName, nil
+	return postRender.connectStrings, installedChart, nil
 }
 
 // TemplateChart generates a helm template from a given chart.
@@ -164,7 +184,7 @@ func (h *Helm) TemplateChart() (string, error) {
 }
 
 // GenerateChart generates a helm chart for a given Zarf manifest.
-func (h *Helm) GenerateChart(manifest types.ZarfManifest) (types.ConnectStrings, string, error) {
+func (h *Helm) GenerateChart(manifest types.ZarfManifest) (types.ConnectStrings, types.InstalledChart, error) {
 	message.Debugf("helm.GenerateChart(%#v)", manifest)
 	spinner := message.NewProgressSpinner("Starting helm chart generation %s", manifest.Name)
 	defer spinner.Stop()
@@ -190,7 +210,7 @@ func (h *Helm) GenerateChart(manifest types.ZarfManifest) (types.ConnectStrings,
 		manifest := fmt.Sprintf("%s/%s", h.BasePath, file)
 		data, err := os.ReadFile(manifest)
 		if err != nil {
-			return nil, "", fmt.Errorf("unable to read manifest file %s: %w", manifest, err)
+			return nil, types.InstalledChart{}, fmt.Errorf("unable to read manifest file %s: %w", manifest, err)
 		}
 		tmpChart.Templates = append(tmpChart.Templates, &chart.File{Name: manifest, Data: data})
 	}
@@ -273,9 +293,69 @@ func (h *Helm) upgradeChart(postRender *renderer) (*release.Release, error) {
 	return client.Run(h.ReleaseName, loadedChart, chartValues)
 }
 
-func (h *Helm) rollbackChart(name string) error {
-	message.Debugf("helm.rollbackChart(%s)", name)
+// ReleaseExists returns whether a release with the given name is installed in the given namespace.
+func (h *Helm) ReleaseExists(namespace string, name string, spinner *message.Spinner) (bool, error) {
+	revision, err := h.ReleaseRevision(namespace, name, spinner)
+	return revision > 0, err
+}
+
+// ReleaseRevision returns the latest revision of a release in the given namespace, or 0 if it is not installed.
+func (h *Helm) ReleaseRevision(namespace string, name string, spinner *message.Spinner) (int, error) {
+	message.Debugf("helm.ReleaseRevision(%s, %s)", namespace, name)
+
+	// Establish a new actionConfig for the namespace
+	if err := h.createActionConfig(namespace, spinner); err != nil {
+		return 0, fmt.Errorf("unable to initialize the K8s client: %w", err)
+	}
+
+	histClient := action.NewHistory(h.actionConfig)
+	histClient.Max = 1
+
+	releases, err := histClient.Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return latestRevision(releases), nil
+}
+
+// latestRevision returns the highest revision of a release history, which helm does not return in order.
+func latestRevision(releases []*release.Release) int {
+	var revision int
+	for _, r := range releases {
+		if r.Version > revision {
+			revision = r.Version
+		}
+	}
+
+	return revision
+}
+
+// RollbackChart rolls back a release in the given namespace to the given revision, or to the previous revision if the
+// revision is 0.
+func (h *Helm) RollbackChart(namespace string, name string, revision int, spinner *message.Spinner) error {
+	message.Debugf("helm.RollbackChart(%s, %s, %d)", namespace, name, revision)
+
+	// Establish a new actionConfig for the namespace
+	if err := h.createActionConfig(namespace, spinner); err != nil {
+		return fmt.Errorf("unable to initialize the K8s client: %w", err)
+	}
+
+	client := action.NewRollback(h.actionConfig)
+	client.CleanupOnFail = true
+	client.Wait = true
+	// Let each chart run for 15 minutes, matching installs and upgrades
+	client.Timeout = 15 * time.Minute
+	client.Version = revision
+	return client.Run(name)
+}
+
+func (h *Helm) rollbackChart(name string, revision int) error {
+	message.Debugf("helm.rollbackChart(%s, %d)", name, revision)
 	client := action.NewRollback(h.actionConfig)
+	client.Version = revision
 	client.CleanupOnFail = true
 	client.Force = true
 	client.Wait = true
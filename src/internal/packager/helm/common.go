@@ -25,6 +25,9 @@ type Helm struct {
 	Cluster           *cluster.Cluster
 	Cfg               *types.PackagerConfig
 
+	// PreviousRevision is the revision of the release before InstallOrUpgradeChart changed it, 0 if it did not exist
+	PreviousRevision int
+
 	actionConfig *action.Configuration
 }
 
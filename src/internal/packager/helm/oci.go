@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package helm contains operations for working with helm charts.
+package helm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+)
+
+// zarfRegistryChartsPath is the repository path in the Zarf registry for charts that do not come from an OCI registry.
+const zarfRegistryChartsPath = "charts"
+
+// IsOCIChart returns true if the chart is pulled from an OCI registry.
+func IsOCIChart(chart types.ZarfChart) bool {
+	return registry.IsOCI(chart.URL)
+}
+
+// OCIChartRef returns the full OCI reference of a chart (without its version), the chart name is appended to the URL
+// if the URL only points to the repository holding the chart.
+func OCIChartRef(chart types.ZarfChart) string {
+	ref := strings.TrimSuffix(chart.URL, "/")
+	if path.Base(ref) != chart.Name {
+		ref = ref + "/" + chart.Name
+	}
+
+	return ref
+}
+
+// zarfRegistryChartPath returns the repository path of a chart in the Zarf registry, OCI charts keep the path they
+// had in their source registry.
+func zarfRegistryChartPath(chart types.ZarfChart) string {
+	if IsOCIChart(chart) {
+		ref := strings.TrimPrefix(OCIChartRef(chart), fmt.Sprintf("%s://", registry.OCIScheme))
+		if _, repository, found := strings.Cut(ref, "/"); found {
+			return repository
+		}
+	}
+
+	return zarfRegistryChartsPath + "/" + chart.Name
+}
+
+// PushChartToRegistry pushes the chart tarball from the given charts path to the Zarf registry as an OCI artifact and
+// returns the reference that can be used to pull it from within the cluster.
+func (h *Helm) PushChartToRegistry(chartsPath string) (string, error) {
+	message.Debugf("helm.PushChartToRegistry(%s)", chartsPath)
+
+	var (
+		err         error
+		tunnel      *cluster.Tunnel
+		target      string
+		regInfo     = h.Cfg.State.RegistryInfo
+		registryURL = regInfo.Address
+	)
+
+	if regInfo.InternalRegistry {
+		// Establish a registry tunnel to send the chart to the zarf registry
+		if tunnel, err = cluster.NewZarfTunnel(); err != nil {
+			return "", err
+		}
+		target = cluster.ZarfRegistry
+	} else if cluster.IsServiceURL(regInfo.Address) {
+		// If this is a serviceURL, create a port-forward tunnel to that resource
+		if tunnel, err = cluster.NewTunnelFromServiceURL(regInfo.Address); err != nil {
+			return "", err
+		}
+	}
+
+	if tunnel != nil {
+		tunnel.Connect(target, false)
+		defer tunnel.Close()
+		registryURL = tunnel.Endpoint()
+	}
+
+	// External registries given as http:// URLs are served over plain HTTP (tunnels are on localhost, which always is)
+	plainHTTP := strings.HasPrefix(registryURL, "http://")
+	registryURL = strings.TrimPrefix(strings.TrimPrefix(registryURL, "http://"), "https://")
+
+	data, err := os.ReadFile(StandardName(chartsPath, h.Chart) + ".tgz")
+	if err != nil {
+		return "", fmt.Errorf("unable to read the chart %s: %w", h.Chart.Name, err)
+	}
+
+	loadedChart, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("unable to load the chart %s: %w", h.Chart.Name, err)
+	}
+
+	// Build the artifact the same way helm push does, so that helm and Flux can pull it
+	memoryStore := content.NewMemory()
+	chartDescriptor, err := memoryStore.Add("", registry.ChartLayerMediaType, data)
+	if err != nil {
+		return "", err
+	}
+
+	configData, err := json.Marshal(loadedChart.Metadata)
+	if err != nil {
+		return "", err
+	}
+	configDescriptor, err := memoryStore.Add("", registry.ConfigMediaType, configData)
+	if err != nil {
+		return "", err
+	}
+
+	manifestData, manifest, err := content.GenerateManifest(&configDescriptor, nil, chartDescriptor)
+	if err != nil {
+		return "", err
+	}
+
+	chartPath := zarfRegistryChartPath(h.Chart)
+	// OCI tags can't contain a + so helm stores semver build metadata with an _ instead
+	ref := fmt.Sprintf("%s/%s:%s", registryURL, chartPath, strings.ReplaceAll(loadedChart.Metadata.Version, "+", "_"))
+	if err := memoryStore.StoreManifest(ref, manifest, manifestData); err != nil {
+		return "", err
+	}
+
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		PlainHTTP: plainHTTP,
+		Credentials: func(string) (string, string, error) {
+			return regInfo.PushUsername, regInfo.PushPassword, nil
+		},
+	})
+
+	if _, err := oras.Copy(context.TODO(), memoryStore, ref, content.Registry{Resolver: resolver}, "", oras.WithNameValidation(nil)); err != nil {
+		return "", fmt.Errorf("unable to push the chart %s to the registry: %w", h.Chart.Name, err)
+	}
+
+	// Pods and controllers in the cluster reach the Zarf registry through its service rather than the tunnel
+	inClusterAddress, _ := cluster.InClusterRegistryAddress(regInfo)
+	return fmt.Sprintf("%s://%s/%s", registry.OCIScheme, inClusterAddress, chartPath), nil
+}
@@ -16,6 +16,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
 )
 
@@ -96,12 +97,23 @@ func (h *Helm) DownloadPublishedChart(destination string) {
 		Getters: getter.All(pull.Settings),
 	}
 
-	// @todo: process OCI-based charts
-
-	// Perform simple chart download
-	chartURL, err := repo.FindChartInRepoURL(h.Chart.URL, h.Chart.Name, h.Chart.Version, pull.CertFile, pull.KeyFile, pull.CaFile, getter.All(pull.Settings))
-	if err != nil {
-		spinner.Fatalf(err, "Unable to pull the helm chart")
+	var chartURL string
+	if IsOCIChart(h.Chart) {
+		// OCI charts are pulled with the credentials of the helm and docker configs
+		registryClient, err := registry.NewClient(registry.ClientOptWriter(spinner))
+		if err != nil {
+			spinner.Fatalf(err, "Unable to create the helm registry client")
+		}
+		chartDownloader.RegistryClient = registryClient
+		chartDownloader.Options = append(chartDownloader.Options, getter.WithRegistryClient(registryClient))
+		chartURL = OCIChartRef(h.Chart)
+	} else {
+		// Perform simple chart download
+		var err error
+		chartURL, err = repo.FindChartInRepoURL(h.Chart.URL, h.Chart.Name, h.Chart.Version, pull.CertFile, pull.KeyFile, pull.CaFile, getter.All(pull.Settings))
+		if err != nil {
+			spinner.Fatalf(err, "Unable to pull the helm chart")
+		}
 	}
 
 	// Download the file (we don't control what name helm creates here)
@@ -4,12 +4,29 @@
 // Package images provides functions for building and pushing images.
 package images
 
-import "github.com/defenseunicorns/zarf/src/types"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	ggcrtypes "github.com/google/go-containerregistry/pkg/v1/types"
+)
 
 // ImgConfig is the main struct for managing container images.
 type ImgConfig struct {
 	TarballPath string
 
+	// ManifestsPath holds the original manifests of the images referenced by digest
+	ManifestsPath string
+
 	ImgList []string
 
 	RegInfo types.RegistryInfo
@@ -18,3 +35,112 @@ type ImgConfig struct {
 
 	Insecure bool
 }
+
+// TarballTag returns the tag an image reference is stored under in the package images tarball.
+func TarballTag(src string) (name.Tag, error) {
+	ref, err := name.ParseReference(src)
+	if err != nil {
+		return name.Tag{}, fmt.Errorf("failed to parse image reference %s: %w", src, err)
+	}
+
+	tag, ok := ref.(name.Tag)
+	if !ok {
+		d, ok := ref.(name.Digest)
+		if !ok {
+			return name.Tag{}, fmt.Errorf("image reference %s wasn't a tag or digest", src)
+		}
+		// Tags cannot contain the digest separator, so keep the digest in the form used by cosign
+		tag = d.Repository.Tag(strings.Replace(d.DigestStr(), ":", "-", 1))
+	}
+
+	return tag, nil
+}
+
+// GetTarballImageDigests returns the manifest digest of every tagged image in an images tarball, keyed by tag.
+// The digests are computed from the image contents so they survive the tarball being rebuilt.
+func GetTarballImageDigests(tarballPath string) (map[string]string, error) {
+	manifest, err := tarball.LoadManifest(func() (io.ReadCloser, error) {
+		return os.Open(tarballPath)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the manifest of %s: %w", tarballPath, err)
+	}
+
+	digests := map[string]string{}
+	for _, descriptor := range manifest {
+		for _, repoTag := range descriptor.RepoTags {
+			tag, err := name.NewTag(repoTag)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse the image tag %s: %w", repoTag, err)
+			}
+
+			img, err := tarball.ImageFromPath(tarballPath, &tag)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load the image %s: %w", repoTag, err)
+			}
+
+			digest, err := img.Digest()
+			if err != nil {
+				return nil, fmt.Errorf("unable to compute the digest of %s: %w", repoTag, err)
+			}
+
+			digests[repoTag] = digest.Hex
+		}
+	}
+
+	return digests, nil
+}
+
+// digestedImage serves the original manifest of an image loaded from a tarball so it is pushed with the same digest.
+type digestedImage struct {
+	v1.Image
+	rawManifest []byte
+}
+
+// RawManifest returns the original manifest of the image.
+func (i *digestedImage) RawManifest() ([]byte, error) {
+	return i.rawManifest, nil
+}
+
+// Manifest returns the parsed original manifest of the image.
+func (i *digestedImage) Manifest() (*v1.Manifest, error) {
+	return v1.ParseManifest(bytes.NewReader(i.rawManifest))
+}
+
+// Digest returns the digest of the original manifest of the image.
+func (i *digestedImage) Digest() (v1.Hash, error) {
+	digest, _, err := v1.SHA256(bytes.NewReader(i.rawManifest))
+	return digest, err
+}
+
+// MediaType returns the media type of the original manifest of the image.
+func (i *digestedImage) MediaType() (ggcrtypes.MediaType, error) {
+	manifest, err := i.Manifest()
+	if err != nil {
+		return "", err
+	}
+	if manifest.MediaType == "" {
+		return ggcrtypes.OCIManifestSchema1, nil
+	}
+	return manifest.MediaType, nil
+}
+
+// readManifests reads the original manifests of the images referenced by digest, keyed by image reference.
+func readManifests(manifestsPath string) (map[string]string, error) {
+	manifests := map[string]string{}
+
+	if manifestsPath == "" || utils.InvalidPath(manifestsPath) {
+		return manifests, nil
+	}
+
+	data, err := os.ReadFile(manifestsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the image manifests: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &manifests); err != nil {
+		return nil, fmt.Errorf("unable to parse the image manifests: %w", err)
+	}
+
+	return manifests, nil
+}
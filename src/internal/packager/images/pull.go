@@ -44,6 +44,7 @@ func (i *ImgConfig) PullAll() (map[name.Tag]v1.Image, error) {
 	defer spinner.Stop()
 
 	imageMap := map[string]v1.Image{}
+	manifests := map[string]string{}
 
 	if message.GetLogLevel() >= message.DebugLevel {
 		logs.Warn.SetOutput(spinner)
@@ -56,28 +57,40 @@ func (i *ImgConfig) PullAll() (map[name.Tag]v1.Image, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to pull image %s: %w", src, err)
 		}
+		// Keep the original manifest of images pinned by digest so they are pushed with the same digest
+		if ref, err := name.ParseReference(src); err == nil {
+			if digestRef, ok := ref.(name.Digest); ok {
+				rawManifest, err := i.getPinnedManifest(src, digestRef, img)
+				if err != nil {
+					return nil, err
+				}
+				manifests[src] = rawManifest
+			}
+		}
+
 		imageCachePath := filepath.Join(config.GetAbsCachePath(), config.ZarfImageCacheDir)
 		img = cache.Image(img, cache.NewFilesystemCache(imageCachePath))
 		imageMap[src] = img
 	}
 
+	if len(manifests) > 0 && i.ManifestsPath != "" {
+		data, err := json.Marshal(manifests)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal the image manifests: %w", err)
+		}
+		if err := utils.WriteFile(i.ManifestsPath, data); err != nil {
+			return nil, fmt.Errorf("unable to write the image manifests: %w", err)
+		}
+	}
+
 	spinner.Updatef("Creating image tarball (this will take a while)")
 
 	tagToImage := map[name.Tag]v1.Image{}
 
 	for src, img := range imageMap {
-		ref, err := name.ParseReference(src)
+		tag, err := TarballTag(src)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse image reference %s: %w", src, err)
-		}
-
-		tag, ok := ref.(name.Tag)
-		if !ok {
-			d, ok := ref.(name.Digest)
-			if !ok {
-				return nil, fmt.Errorf("image reference %s wasn't a tag or digest", src)
-			}
-			tag = d.Repository.Tag("digest-only")
+			return nil, err
 		}
 		tagToImage[tag] = img
 	}
@@ -118,6 +131,27 @@ func (i *ImgConfig) PullAll() (map[name.Tag]v1.Image, error) {
 	return tagToImage, nil
 }
 
+// getPinnedManifest returns the raw manifest of an image referenced by digest, the digest must be of the image itself.
+func (i *ImgConfig) getPinnedManifest(src string, digestRef name.Digest, img v1.Image) (string, error) {
+	digest, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the digest of %s: %w", src, err)
+	}
+
+	// A digest of a multi-platform index resolves to a single platform image that cannot be pushed with that digest
+	if digest.String() != digestRef.DigestStr() {
+		return "", fmt.Errorf("image %s is pinned to a multi-platform index, pin it to the digest of the %s image (%s) instead",
+			src, config.GetArch(), digest.String())
+	}
+
+	rawManifest, err := img.RawManifest()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the manifest of %s: %w", src, err)
+	}
+
+	return string(rawManifest), nil
+}
+
 // FormatCraneOCILayout ensures that all images are in the OCI format.
 func FormatCraneOCILayout(ociPath string) error {
 	type IndexJSON struct {
@@ -14,6 +14,7 @@ import (
 
 // PushToZarfRegistry pushes a provided image into the configured Zarf registry
 // This function will optionally shorten the image name while appending a checksum of the original image name.
+// Images referenced by digest are pushed by that same digest.
 func (i *ImgConfig) PushToZarfRegistry() error {
 	message.Debugf("images.PushToZarfRegistry(%#v)", i)
 
@@ -49,12 +50,26 @@ func (i *ImgConfig) PushToZarfRegistry() error {
 	pushOptions := config.GetCraneAuthOption(i.RegInfo.PushUsername, i.RegInfo.PushPassword)
 	message.Debugf("crane pushOptions = %#v", pushOptions)
 
+	manifests, err := readManifests(i.ManifestsPath)
+	if err != nil {
+		return err
+	}
+
 	for _, src := range i.ImgList {
 		spinner.Updatef("Updating image %s", src)
-		img, err := crane.LoadTag(i.TarballPath, src, config.GetCraneOptions(i.Insecure)...)
+		tag, err := TarballTag(src)
 		if err != nil {
 			return err
 		}
+		img, err := crane.LoadTag(i.TarballPath, tag.String(), config.GetCraneOptions(i.Insecure)...)
+		if err != nil {
+			return err
+		}
+
+		// Images pinned by digest are pushed by that digest, so they need their original manifest
+		if rawManifest, ok := manifests[src]; ok {
+			img = &digestedImage{Image: img, rawManifest: []byte(rawManifest)}
+		}
 		offlineName := ""
 		if i.NoChecksum {
 			offlineName, err = utils.SwapHostWithoutChecksum(src, registryURL)
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// URLPrefix is the scheme prefix used to reference a Zarf package in an OCI registry.
+	URLPrefix = "oci://"
+
+	// ZarfConfigMediaType is the media type of the (empty) config of a Zarf package artifact.
+	ZarfConfigMediaType types.MediaType = "application/vnd.zarf.config.v1+json"
+	// ZarfLayerMediaTypeBlob is the media type of a single file layer within a Zarf package artifact.
+	ZarfLayerMediaTypeBlob types.MediaType = "application/vnd.zarf.layer.v1.blob"
+	// ZarfLayerMediaTypeTar is the media type of a tarred directory layer within a Zarf package artifact.
+	ZarfLayerMediaTypeTar types.MediaType = "application/vnd.zarf.layer.v1.tar"
+	// ZarfLayerMediaTypeImage is the media type of a single image tarball layer within a Zarf package artifact.
+	ZarfLayerMediaTypeImage types.MediaType = "application/vnd.zarf.layer.v1.image"
+
+	// TitleAnnotation is the OCI annotation used to store the relative path of a layer within the package.
+	TitleAnnotation = "org.opencontainers.image.title"
+	// DescriptionAnnotation is the OCI annotation used to store the description of the package.
+	DescriptionAnnotation = "org.opencontainers.image.description"
+	// VersionAnnotation is the OCI annotation used to store the version of the package.
+	VersionAnnotation = "org.opencontainers.image.version"
+	// RefNameAnnotation is the OCI annotation used to name a package within a local OCI image layout.
+	RefNameAnnotation = "org.opencontainers.image.ref.name"
+	// ImageTagsAnnotation is the annotation used to store the comma-separated tags of the image in an image layer.
+	ImageTagsAnnotation = "dev.zarf.image.tags"
+
+	// ComponentsDir is the directory within the package that holds one tarball layer per component.
+	ComponentsDir = "components"
+	// ImagesDir is the directory within the package artifact that holds one tarball layer per image.
+	ImagesDir = "images"
+)
+
+// IsOCIURL returns true if the given string references a package in an OCI registry.
+func IsOCIURL(source string) bool {
+	return strings.HasPrefix(source, URLPrefix)
+}
+
+// IsLayout returns true if the given path is a local OCI image layout directory.
+func IsLayout(path string) bool {
+	_, err := os.Stat(filepath.Join(path, "oci-layout"))
+	return err == nil
+}
+
+// ParseReference parses an oci:// URL (or a plain registry reference) into a name.Reference.
+func ParseReference(source string, insecure bool) (name.Reference, error) {
+	message.Debugf("oci.ParseReference(%s, %t)", source, insecure)
+
+	var opts []name.Option
+	if insecure {
+		opts = append(opts, name.Insecure)
+	}
+
+	ref, err := name.ParseReference(strings.TrimPrefix(source, URLPrefix), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the OCI reference %s: %w", source, err)
+	}
+
+	return ref, nil
+}
+
+// ParseRepository parses an oci:// URL (or a plain registry reference) into a name.Repository.
+func ParseRepository(source string, insecure bool) (name.Repository, error) {
+	message.Debugf("oci.ParseRepository(%s, %t)", source, insecure)
+
+	var opts []name.Option
+	if insecure {
+		opts = append(opts, name.Insecure)
+	}
+
+	repo, err := name.NewRepository(strings.TrimSuffix(strings.TrimPrefix(source, URLPrefix), "/"), opts...)
+	if err != nil {
+		return repo, fmt.Errorf("unable to parse the OCI repository %s: %w", source, err)
+	}
+
+	return repo, nil
+}
+
+// RemoteOptions returns the remote options used for all registry calls, authenticating with the local docker config.
+func RemoteOptions() []remote.Option {
+	return []remote.Option{
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+}
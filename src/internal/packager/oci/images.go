@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// SplitImageTarball splits the package images tarball into one tarball layer per image so that images can be pulled
+// individually, each layer is annotated with the tags of its image.
+func SplitImageTarball(tarballPath string, stagingDir string) ([]Layer, error) {
+	message.Debugf("oci.SplitImageTarball(%s, %s)", tarballPath, stagingDir)
+
+	var layers []Layer
+
+	refToImages, err := loadImageTarball(tarballPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.CreateDirectory(filepath.Join(stagingDir, ImagesDir), 0700); err != nil {
+		return nil, err
+	}
+
+	for tags, refToImage := range refToImages {
+		var img v1.Image
+		for _, img = range refToImage {
+			break
+		}
+
+		digest, err := img.Digest()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the digest of %s: %w", tags, err)
+		}
+
+		title := ImagesDir + "/" + digest.Hex + ".tar"
+		path := filepath.Join(stagingDir, title)
+		if err := tarball.MultiRefWriteToFile(path, refToImage); err != nil {
+			return nil, fmt.Errorf("unable to write the image tarball for %s: %w", tags, err)
+		}
+
+		layers = append(layers, Layer{
+			Path:        path,
+			Title:       title,
+			MediaType:   ZarfLayerMediaTypeImage,
+			Annotations: map[string]string{ImageTagsAnnotation: tags},
+		})
+	}
+
+	return layers, nil
+}
+
+// MergeImageTarballs combines pulled image tarball layers back into a single package images tarball.
+func MergeImageTarballs(paths []string, tarballPath string) error {
+	message.Debugf("oci.MergeImageTarballs(%#v, %s)", paths, tarballPath)
+
+	spinner := message.NewProgressSpinner("Combining %d images into %s", len(paths), filepath.Base(tarballPath))
+	defer spinner.Stop()
+
+	combined := map[name.Reference]v1.Image{}
+	for _, path := range paths {
+		refToImages, err := loadImageTarball(path)
+		if err != nil {
+			return err
+		}
+
+		for _, refToImage := range refToImages {
+			for ref, img := range refToImage {
+				combined[ref] = img
+			}
+		}
+	}
+
+	if err := tarball.MultiRefWriteToFile(tarballPath, combined); err != nil {
+		return fmt.Errorf("unable to write the images tarball: %w", err)
+	}
+
+	spinner.Success()
+	return nil
+}
+
+// loadImageTarball reads every image in a docker image tarball, grouped by the comma-separated tags of each image.
+func loadImageTarball(tarballPath string) (map[string]map[name.Reference]v1.Image, error) {
+	manifest, err := tarball.LoadManifest(func() (io.ReadCloser, error) {
+		return os.Open(tarballPath)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the manifest of %s: %w", tarballPath, err)
+	}
+
+	images := map[string]map[name.Reference]v1.Image{}
+	for _, descriptor := range manifest {
+		if len(descriptor.RepoTags) == 0 {
+			continue
+		}
+
+		var img v1.Image
+		refToImage := map[name.Reference]v1.Image{}
+		for _, repoTag := range descriptor.RepoTags {
+			tag, err := name.NewTag(repoTag)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse the image tag %s: %w", repoTag, err)
+			}
+
+			// Share a single image across all of its tags so it is written once
+			if img == nil {
+				if img, err = tarball.ImageFromPath(tarballPath, &tag); err != nil {
+					return nil, fmt.Errorf("unable to load the image %s from %s: %w", repoTag, tarballPath, err)
+				}
+			}
+			refToImage[tag] = img
+		}
+
+		images[strings.Join(descriptor.RepoTags, ",")] = refToImage
+	}
+
+	return images, nil
+}
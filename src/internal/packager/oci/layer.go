@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"io"
+	"os"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// fileLayer is a v1.Layer backed by a file on disk that is stored in the registry as-is (without compression).
+type fileLayer struct {
+	path      string
+	digest    v1.Hash
+	size      int64
+	mediaType types.MediaType
+}
+
+// newFileLayer creates a layer from the file at the given path.
+func newFileLayer(path string, mediaType types.MediaType) (*fileLayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	digest, size, err := v1.SHA256(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileLayer{
+		path:      path,
+		digest:    digest,
+		size:      size,
+		mediaType: mediaType,
+	}, nil
+}
+
+// Digest returns the sha256 of the file.
+func (l *fileLayer) Digest() (v1.Hash, error) {
+	return l.digest, nil
+}
+
+// DiffID returns the sha256 of the file, since the layer is not compressed.
+func (l *fileLayer) DiffID() (v1.Hash, error) {
+	return l.digest, nil
+}
+
+// Compressed returns the contents of the file.
+func (l *fileLayer) Compressed() (io.ReadCloser, error) {
+	return os.Open(l.path)
+}
+
+// Uncompressed returns the contents of the file.
+func (l *fileLayer) Uncompressed() (io.ReadCloser, error) {
+	return os.Open(l.path)
+}
+
+// Size returns the size of the file.
+func (l *fileLayer) Size() (int64, error) {
+	return l.size, nil
+}
+
+// MediaType returns the media type of the layer.
+func (l *fileLayer) MediaType() (types.MediaType, error) {
+	return l.mediaType, nil
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Package is a published Zarf package artifact in a remote registry or a local OCI image layout.
+type Package struct {
+	// Source is the registry reference or layout path the package was read from
+	Source   string
+	Manifest *v1.Manifest
+	Digest   v1.Hash
+
+	blob func(v1.Hash) (io.ReadCloser, error)
+}
+
+// NewRemotePackage reads the manifest of the Zarf package artifact at the given registry reference.
+func NewRemotePackage(ref name.Reference) (*Package, error) {
+	message.Debugf("oci.NewRemotePackage(%s)", ref)
+
+	desc, err := remote.Get(ref, RemoteOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the manifest for %s: %w", ref, err)
+	}
+
+	manifest, err := parseManifest(ref.String(), desc.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := ref.Context()
+	return &Package{
+		Source:   ref.String(),
+		Manifest: manifest,
+		Digest:   desc.Digest,
+		blob: func(h v1.Hash) (io.ReadCloser, error) {
+			// The layers are stored uncompressed, so the "compressed" stream is the raw (digest verified) blob
+			layer, err := remote.Layer(repo.Digest(h.String()), RemoteOptions()...)
+			if err != nil {
+				return nil, err
+			}
+			return layer.Compressed()
+		},
+	}, nil
+}
+
+// NewLayoutPackage reads the manifest of the Zarf package stored in the local OCI image layout at the given path.
+func NewLayoutPackage(path string) (*Package, error) {
+	message.Debugf("oci.NewLayoutPackage(%s)", path)
+
+	layoutPath, err := layout.FromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the OCI layout at %s: %w", path, err)
+	}
+
+	index, err := layoutPath.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the index of the OCI layout at %s: %w", path, err)
+	}
+
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the index of the OCI layout at %s: %w", path, err)
+	}
+
+	// A layout written by 'zarf package publish' holds exactly one package
+	if len(indexManifest.Manifests) != 1 {
+		return nil, fmt.Errorf("expected the OCI layout at %s to contain one package, found %d manifests", path, len(indexManifest.Manifests))
+	}
+
+	desc := indexManifest.Manifests[0]
+	rawManifest, err := layoutPath.Bytes(desc.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the manifest %s: %w", desc.Digest, err)
+	}
+
+	manifest, err := parseManifest(path, rawManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		Source:   path,
+		Manifest: manifest,
+		Digest:   desc.Digest,
+		blob:     layoutPath.Blob,
+	}, nil
+}
+
+// Layer returns the layer with the given title.
+func (pkg *Package) Layer(title string) (v1.Descriptor, bool) {
+	for _, layer := range pkg.Manifest.Layers {
+		if layer.Annotations[TitleAnnotation] == title {
+			return layer, true
+		}
+	}
+
+	return v1.Descriptor{}, false
+}
+
+// ComponentLayers returns the layers needed to deploy only the given components: every layer that is not a
+// component or an image (zarf.yaml, SBOMs, etc), the tarballs of the given components and the layers holding any of
+// the given image tags.
+func (pkg *Package) ComponentLayers(components []string, imageTags []string) []v1.Descriptor {
+	var layers []v1.Descriptor
+
+	wantedComponents := map[string]bool{}
+	for _, component := range components {
+		wantedComponents[ComponentsDir+"/"+component+".tar"] = true
+	}
+
+	wantedTags := map[string]bool{}
+	for _, tag := range imageTags {
+		wantedTags[normalizeTag(tag)] = true
+	}
+
+	for _, layer := range pkg.Manifest.Layers {
+		title := layer.Annotations[TitleAnnotation]
+
+		switch {
+		case layer.MediaType == ZarfLayerMediaTypeImage:
+			for _, tag := range strings.Split(layer.Annotations[ImageTagsAnnotation], ",") {
+				if wantedTags[normalizeTag(tag)] {
+					layers = append(layers, layer)
+					break
+				}
+			}
+
+		case strings.HasPrefix(title, ComponentsDir+"/"):
+			if wantedComponents[title] {
+				layers = append(layers, layer)
+			}
+
+		default:
+			layers = append(layers, layer)
+		}
+	}
+
+	return layers
+}
+
+func parseManifest(source string, rawManifest []byte) (*v1.Manifest, error) {
+	manifest, err := v1.ParseManifest(bytes.NewReader(rawManifest))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the manifest for %s: %w", source, err)
+	}
+
+	if manifest.Config.MediaType != ZarfConfigMediaType {
+		return nil, fmt.Errorf("%s is not a Zarf package (config media type %s)", source, manifest.Config.MediaType)
+	}
+
+	return manifest, nil
+}
+
+// normalizeTag returns the fully qualified form of an image tag so that equivalent tags compare as equal.
+func normalizeTag(tag string) string {
+	parsed, err := name.NewTag(tag)
+	if err != nil {
+		return tag
+	}
+	return parsed.Name()
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLayer(title string, mediaType types.MediaType, tags string) v1.Descriptor {
+	annotations := map[string]string{TitleAnnotation: title}
+	if tags != "" {
+		annotations[ImageTagsAnnotation] = tags
+	}
+	return v1.Descriptor{MediaType: mediaType, Annotations: annotations}
+}
+
+func TestComponentLayers(t *testing.T) {
+	pkg := &Package{Manifest: &v1.Manifest{Layers: []v1.Descriptor{
+		newTestLayer("zarf.yaml", ZarfLayerMediaTypeBlob, ""),
+		newTestLayer("checksums.txt", ZarfLayerMediaTypeBlob, ""),
+		newTestLayer("sboms.tar", ZarfLayerMediaTypeTar, ""),
+		newTestLayer("components/first.tar", ZarfLayerMediaTypeTar, ""),
+		newTestLayer("components/second.tar", ZarfLayerMediaTypeTar, ""),
+		newTestLayer("images/0.tar", ZarfLayerMediaTypeImage, "nginx:1.23,docker.io/library/nginx:stable"),
+		newTestLayer("images/1.tar", ZarfLayerMediaTypeImage, "ghcr.io/stefanprodan/podinfo:6.3.0"),
+	}}}
+
+	titles := func(layers []v1.Descriptor) []string {
+		var result []string
+		for _, layer := range layers {
+			result = append(result, layer.Annotations[TitleAnnotation])
+		}
+		return result
+	}
+
+	tests := []struct {
+		name       string
+		components []string
+		imageTags  []string
+		want       []string
+	}{
+		{
+			name: "no components",
+			want: []string{"zarf.yaml", "checksums.txt", "sboms.tar"},
+		},
+		{
+			name:       "one component with a normalized image tag",
+			components: []string{"first"},
+			imageTags:  []string{"index.docker.io/library/nginx:stable"},
+			want:       []string{"zarf.yaml", "checksums.txt", "sboms.tar", "components/first.tar", "images/0.tar"},
+		},
+		{
+			name:       "every component and image",
+			components: []string{"first", "second"},
+			imageTags:  []string{"nginx:1.23", "ghcr.io/stefanprodan/podinfo:6.3.0"},
+			want:       []string{"zarf.yaml", "checksums.txt", "sboms.tar", "components/first.tar", "components/second.tar", "images/0.tar", "images/1.tar"},
+		},
+		{
+			name:       "unknown component and image",
+			components: []string{"third"},
+			imageTags:  []string{"busybox:1.35"},
+			want:       []string{"zarf.yaml", "checksums.txt", "sboms.tar"},
+		},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, titles(pkg.ComponentLayers(tt.components, tt.imageTags)), tt.name)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	_, err := parseManifest("zarf", []byte(`{"schemaVersion":2,"config":{"mediaType":"application/vnd.zarf.config.v1+json"},"layers":[]}`))
+	require.NoError(t, err)
+
+	_, err = parseManifest("image", []byte(`{"schemaVersion":2,"config":{"mediaType":"application/vnd.oci.image.config.v1+json"},"layers":[]}`))
+	require.ErrorContains(t, err, "is not a Zarf package")
+
+	_, err = parseManifest("invalid", []byte("not a manifest"))
+	require.ErrorContains(t, err, "unable to parse the manifest")
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/mholt/archiver/v3"
+)
+
+// PullLayers downloads the given layers of the package into the destination directory, expanding tarred directories.
+func (pkg *Package) PullLayers(layers []v1.Descriptor, destination string) error {
+	message.Debugf("oci.PullLayers(%s, %#v, %s)", pkg.Source, layers, destination)
+
+	var totalSize int64
+	for _, layer := range layers {
+		totalSize += layer.Size
+	}
+
+	progressBar := message.NewProgressBar(totalSize, "Pulling %d layers (%s) from %s", len(layers), utils.ByteFormat(float64(totalSize), 2), pkg.Source)
+	defer progressBar.Stop()
+
+	for _, layer := range layers {
+		if err := pkg.pullLayer(layer, destination, progressBar); err != nil {
+			return err
+		}
+	}
+
+	progressBar.Success("Pulled %d layers (%s) from %s", len(layers), utils.ByteFormat(float64(totalSize), 2), pkg.Source)
+	return nil
+}
+
+func (pkg *Package) pullLayer(desc v1.Descriptor, destination string, progressBar *message.ProgressBar) error {
+	title := desc.Annotations[TitleAnnotation]
+	if !isLocalPath(title) {
+		return fmt.Errorf("layer %s has an invalid title %q", desc.Digest, title)
+	}
+
+	reader, err := pkg.blob(desc.Digest)
+	if err != nil {
+		return fmt.Errorf("unable to read layer %s: %w", title, err)
+	}
+	defer reader.Close()
+
+	path := filepath.Join(destination, title)
+	if err := utils.CreateDirectory(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("unable to create the directory for %s: %w", title, err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create %s: %w", path, err)
+	}
+
+	_, err = io.Copy(io.MultiWriter(file, progressBar), reader)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("unable to write layer %s: %w", title, err)
+	}
+
+	if desc.MediaType != ZarfLayerMediaTypeTar {
+		return nil
+	}
+
+	// Directories are stored as tarballs that contain the directory itself, so expand them next to the tarball
+	if err := archiver.Unarchive(path, filepath.Dir(path)); err != nil {
+		return fmt.Errorf("unable to extract layer %s: %w", title, err)
+	}
+
+	return os.Remove(path)
+}
+
+// isLocalPath ensures a layer title cannot write outside of the destination directory.
+func isLocalPath(path string) bool {
+	if path == "" || filepath.IsAbs(path) {
+		return false
+	}
+
+	cleaned := filepath.Clean(path)
+	return cleaned != ".." && !strings.HasPrefix(cleaned, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLocalPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "zarf.yaml", want: true},
+		{path: "components/app.tar", want: true},
+		{path: "components/../zarf.yaml", want: true},
+		{path: "..data", want: true},
+		{path: "", want: false},
+		{path: "/etc/passwd", want: false},
+		{path: "..", want: false},
+		{path: "../zarf.yaml", want: false},
+		{path: "components/../../zarf.yaml", want: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, isLocalPath(tt.path), tt.path)
+	}
+}
+
+func TestLayoutRoundTrip(t *testing.T) {
+	source := t.TempDir()
+
+	zarfYaml := filepath.Join(source, "zarf.yaml")
+	require.NoError(t, os.WriteFile(zarfYaml, []byte("kind: ZarfPackageConfig\n"), 0600))
+
+	componentDir := filepath.Join(source, "components", "app")
+	require.NoError(t, os.MkdirAll(filepath.Join(componentDir, "files"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(componentDir, "files", "0"), []byte("data"), 0600))
+	componentTar := filepath.Join(source, "components", "app.tar")
+	require.NoError(t, archiver.Archive([]string{componentDir}, componentTar))
+
+	layers := []Layer{
+		{Path: zarfYaml, Title: "zarf.yaml", MediaType: ZarfLayerMediaTypeBlob},
+		{Path: componentTar, Title: "components/app.tar", MediaType: ZarfLayerMediaTypeTar},
+	}
+
+	layoutDir := filepath.Join(t.TempDir(), "layout")
+	digest, err := WriteLayout(layoutDir, layers, map[string]string{RefNameAnnotation: "app:1.0.0"})
+	require.NoError(t, err)
+	require.True(t, IsLayout(layoutDir))
+
+	pkg, err := NewLayoutPackage(layoutDir)
+	require.NoError(t, err)
+	require.Equal(t, digest, pkg.Digest)
+	require.Len(t, pkg.Manifest.Layers, 2)
+
+	destination := t.TempDir()
+	require.NoError(t, pkg.PullLayers(pkg.Manifest.Layers, destination))
+
+	content, err := os.ReadFile(filepath.Join(destination, "zarf.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "kind: ZarfPackageConfig\n", string(content))
+
+	// Tarred directories are expanded and their tarball removed
+	content, err = os.ReadFile(filepath.Join(destination, "components", "app", "files", "0"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(content))
+	require.NoFileExists(t, filepath.Join(destination, "components", "app.tar"))
+
+	// A layout is only written over an existing layout or an empty directory
+	_, err = WriteLayout(source, layers, nil)
+	require.ErrorContains(t, err, "is not empty and is not an OCI image layout")
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package oci contains functions for publishing and pulling Zarf packages as OCI artifacts.
+package oci
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// Layer describes a file that is published as a single layer of a Zarf package artifact.
+type Layer struct {
+	// Path is the location of the file on disk
+	Path string
+	// Title is the path of the content relative to the root of the extracted package
+	Title string
+	// MediaType is one of the ZarfLayerMediaType* media types
+	MediaType types.MediaType
+	// Annotations are additional annotations to add to the layer descriptor
+	Annotations map[string]string
+}
+
+// Push publishes the given layers as an OCI artifact to the provided reference and returns the manifest digest.
+func Push(ref name.Reference, layers []Layer, annotations map[string]string) (v1.Hash, error) {
+	message.Debugf("oci.Push(%s, %#v, %#v)", ref, layers, annotations)
+
+	img, totalSize, err := buildArtifact(layers, annotations)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	spinner := message.NewProgressSpinner("Publishing %d layers (%s) to %s", len(layers), utils.ByteFormat(float64(totalSize), 2), ref)
+	defer spinner.Stop()
+
+	if err := remote.Write(ref, img, RemoteOptions()...); err != nil {
+		return v1.Hash{}, fmt.Errorf("unable to push the package to %s: %w", ref, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("unable to get the digest of the published package: %w", err)
+	}
+
+	spinner.Successf("Published %s@%s", ref.Context(), digest)
+	return digest, nil
+}
+
+// WriteLayout writes the given layers as an OCI artifact into a new local OCI image layout and returns the manifest digest.
+func WriteLayout(path string, layers []Layer, annotations map[string]string) (v1.Hash, error) {
+	message.Debugf("oci.WriteLayout(%s, %#v, %#v)", path, layers, annotations)
+
+	// Only create a fresh layout or replace an existing one, never write into an unrelated directory
+	if !utils.InvalidPath(path) && !IsLayout(path) {
+		if entries, err := os.ReadDir(path); err != nil || len(entries) > 0 {
+			return v1.Hash{}, fmt.Errorf("%s is not empty and is not an OCI image layout", path)
+		}
+	}
+
+	img, totalSize, err := buildArtifact(layers, annotations)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	spinner := message.NewProgressSpinner("Writing %d layers (%s) to %s", len(layers), utils.ByteFormat(float64(totalSize), 2), path)
+	defer spinner.Stop()
+
+	// A layout holds a single package, so start from an empty index
+	layoutPath, err := layout.Write(path, empty.Index)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("unable to create the OCI layout at %s: %w", path, err)
+	}
+
+	if err := layoutPath.AppendImage(img, layout.WithAnnotations(map[string]string{RefNameAnnotation: annotations[RefNameAnnotation]})); err != nil {
+		return v1.Hash{}, fmt.Errorf("unable to write the package to %s: %w", path, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("unable to get the digest of the published package: %w", err)
+	}
+
+	spinner.Successf("Wrote %s@%s", path, digest)
+	return digest, nil
+}
+
+// buildArtifact assembles the package artifact from the given layers and returns it with the total size of the layers.
+func buildArtifact(layers []Layer, annotations map[string]string) (v1.Image, int64, error) {
+	var (
+		adds      []mutate.Addendum
+		totalSize int64
+	)
+
+	for _, layer := range layers {
+		fl, err := newFileLayer(layer.Path, layer.MediaType)
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to create a layer from %s: %w", layer.Path, err)
+		}
+		totalSize += fl.size
+
+		layerAnnotations := map[string]string{TitleAnnotation: layer.Title}
+		for key, value := range layer.Annotations {
+			layerAnnotations[key] = value
+		}
+
+		adds = append(adds, mutate.Addendum{
+			Layer:       fl,
+			MediaType:   layer.MediaType,
+			Annotations: layerAnnotations,
+		})
+	}
+
+	img, err := mutate.Append(empty.Image, adds...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to build the package artifact: %w", err)
+	}
+
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, ZarfConfigMediaType)
+	img = mutate.Annotations(img, annotations).(v1.Image)
+
+	return img, totalSize, nil
+}
@@ -11,10 +11,13 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/pkg/k8s"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Run performs config validations.
@@ -27,6 +30,12 @@ func Run(pkg types.ZarfPackage) error {
 		return fmt.Errorf(lang.PkgValidateErrName, err)
 	}
 
+	for _, requirement := range pkg.Metadata.Requires {
+		if err := validatePackageRequirement(pkg, requirement); err != nil {
+			return fmt.Errorf(lang.PkgValidateErrRequirement, err)
+		}
+	}
+
 	for _, variable := range pkg.Variables {
 		if err := validatePackageVariable(variable); err != nil {
 			return fmt.Errorf(lang.PkgValidateErrVariable, err)
@@ -53,6 +62,10 @@ func Run(pkg types.ZarfPackage) error {
 		}
 	}
 
+	if err := validateDependencies(pkg.Components); err != nil {
+		return fmt.Errorf(lang.PkgValidateErrComponent, err)
+	}
+
 	return nil
 }
 
@@ -91,6 +104,16 @@ func oneIfNotEmpty(testString string) int {
 	return 1
 }
 
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validateComponent(pkg types.ZarfPackage, component types.ZarfComponent) error {
 	if component.Required {
 		if component.Default {
@@ -101,6 +124,12 @@ func validateComponent(pkg types.ZarfPackage, component types.ZarfComponent) err
 		}
 	}
 
+	for _, distro := range component.Only.Cluster.Distros {
+		if !k8s.IsValidDistroPattern(distro) {
+			return fmt.Errorf(lang.PkgValidateErrComponentDistro, component.Name, distro, strings.Join(k8s.Distros, ", "))
+		}
+	}
+
 	for _, chart := range component.Charts {
 		if err := validateChart(chart); err != nil {
 			return fmt.Errorf(lang.PkgValidateErrChart, err)
@@ -113,6 +142,12 @@ func validateComponent(pkg types.ZarfPackage, component types.ZarfComponent) err
 		}
 	}
 
+	for _, wait := range component.Wait {
+		if err := validateWait(wait); err != nil {
+			return fmt.Errorf(lang.PkgValidateErrWait, err)
+		}
+	}
+
 	if pkg.Metadata.YOLO {
 		if err := validateYOLO(component); err != nil {
 			return fmt.Errorf(lang.PkgValidateErrComponentYOLO, component.Name, err)
@@ -122,6 +157,65 @@ func validateComponent(pkg types.ZarfPackage, component types.ZarfComponent) err
 	return nil
 }
 
+func validateDependencies(components []types.ZarfComponent) error {
+	componentsByName := make(map[string]types.ZarfComponent)
+	for _, component := range components {
+		componentsByName[component.Name] = component
+	}
+
+	for _, component := range components {
+		for _, dependency := range component.DependsOn {
+			dependencyComponent, ok := componentsByName[dependency]
+			if !ok {
+				return fmt.Errorf(lang.PkgValidateErrDependencyMissing, component.Name, dependency)
+			}
+
+			// Including a grouped component automatically would bypass the choice of the user
+			if dependencyComponent.Group != "" {
+				return fmt.Errorf(lang.PkgValidateErrDependencyGrouped, component.Name, dependency)
+			}
+		}
+	}
+
+	// Walk the dependencies depth first, a component that is reached again while its own dependencies are being
+	// walked is part of a cycle
+	const (
+		visiting = iota + 1
+		visited
+	)
+	visits := make(map[string]int)
+
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		chain = append(chain, name)
+
+		switch visits[name] {
+		case visiting:
+			return fmt.Errorf(lang.PkgValidateErrDependencyCycle, strings.Join(chain, " -> "))
+		case visited:
+			return nil
+		}
+
+		visits[name] = visiting
+		for _, dependency := range componentsByName[name].DependsOn {
+			if err := visit(dependency, chain); err != nil {
+				return err
+			}
+		}
+		visits[name] = visited
+
+		return nil
+	}
+
+	for _, component := range components {
+		if err := visit(component.Name, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateYOLO(component types.ZarfComponent) error {
 	if len(component.Images) > 0 {
 		return fmt.Errorf(lang.PkgValidateErrYOLONoOCI)
@@ -131,6 +225,12 @@ func validateYOLO(component types.ZarfComponent) error {
 		return fmt.Errorf(lang.PkgValidateErrYOLONoGit)
 	}
 
+	for _, chart := range component.Charts {
+		if chart.PushToRegistry {
+			return fmt.Errorf(lang.PkgValidateErrYOLONoChart)
+		}
+	}
+
 	if component.Only.Cluster.Architecture != "" {
 		return fmt.Errorf(lang.PkgValidateErrYOLONoArch)
 	}
@@ -153,6 +253,24 @@ func validatePackageName(subject string) error {
 	return nil
 }
 
+func validatePackageRequirement(pkg types.ZarfPackage, requirement types.ZarfPackageRequirement) error {
+	if err := validatePackageName(requirement.Name); err != nil {
+		return err
+	}
+
+	if requirement.Name == pkg.Metadata.Name {
+		return fmt.Errorf(lang.PkgValidateErrRequirementSelf, requirement.Name)
+	}
+
+	if requirement.Version != "" {
+		if _, err := semver.NewConstraint(requirement.Version); err != nil {
+			return fmt.Errorf(lang.PkgValidateErrRequirementVersion, requirement.Version, requirement.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func validatePackageVariable(subject types.ZarfPackageVariable) error {
 	isAllCapsUnderscore := regexp.MustCompile(`^[A-Z0-9_]+$`).MatchString
 
@@ -223,3 +341,42 @@ func validateManifest(manifest types.ZarfManifest) error {
 
 	return nil
 }
+
+func validateWait(wait types.ZarfComponentWait) error {
+	target := k8s.WaitCondition{Kind: wait.Kind, Name: wait.Name, Namespace: wait.Namespace, Selector: wait.Selector}
+
+	// Must know what kind of resource to look for
+	if wait.Kind == "" {
+		return fmt.Errorf(lang.PkgValidateErrWaitKindMissing)
+	}
+
+	// Must only have a name or selector
+	count := oneIfNotEmpty(wait.Name) + oneIfNotEmpty(wait.Selector)
+	if count != 1 {
+		return fmt.Errorf(lang.PkgValidateErrWaitNameOrSelector, target)
+	}
+
+	if wait.Selector != "" {
+		if _, err := labels.Parse(wait.Selector); err != nil {
+			return fmt.Errorf(lang.PkgValidateErrWaitSelector, target, wait.Selector, err)
+		}
+	}
+
+	// Built-in workloads only ever have some conditions, waiting on any other would always time out
+	if conditions, ok := k8s.ValidConditions(wait.Kind); ok && wait.Condition != "" && !containsFold(conditions, wait.Condition) {
+		return fmt.Errorf(lang.PkgValidateErrWaitCondition, target, wait.Condition, conditions)
+	}
+
+	// A jsonPath is only useful with a value to compare it to
+	if (wait.JSONPath == "") != (wait.Value == "") {
+		return fmt.Errorf(lang.PkgValidateErrWaitJSONPathValue, target)
+	}
+
+	if wait.JSONPath != "" {
+		if _, err := k8s.ParseJSONPath(wait.JSONPath); err != nil {
+			return fmt.Errorf(lang.PkgValidateErrWaitJSONPath, target, wait.JSONPath, err)
+		}
+	}
+
+	return nil
+}
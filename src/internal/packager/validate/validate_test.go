@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package validate provides Zarf package validation functions.
+package validate
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []types.ZarfComponent
+		wantErr    string
+	}{
+		{
+			name: "no dependencies",
+			components: []types.ZarfComponent{
+				{Name: "first"},
+				{Name: "second"},
+			},
+		},
+		{
+			name: "chained dependencies",
+			components: []types.ZarfComponent{
+				{Name: "app", DependsOn: []string{"database"}},
+				{Name: "database", DependsOn: []string{"storage"}},
+				{Name: "storage"},
+			},
+		},
+		{
+			name: "shared dependency",
+			components: []types.ZarfComponent{
+				{Name: "storage"},
+				{Name: "database", DependsOn: []string{"storage"}},
+				{Name: "cache", DependsOn: []string{"storage"}},
+				{Name: "app", DependsOn: []string{"database", "cache"}},
+			},
+		},
+		{
+			name: "missing dependency",
+			components: []types.ZarfComponent{
+				{Name: "app", DependsOn: []string{"database"}},
+			},
+			wantErr: "component app depends on database which is not a component of this package",
+		},
+		{
+			name: "grouped dependency",
+			components: []types.ZarfComponent{
+				{Name: "app", DependsOn: []string{"database"}},
+				{Name: "database", Group: "storage"},
+			},
+			wantErr: "component app cannot depend on database because it is part of a choice group",
+		},
+		{
+			name: "self dependency",
+			components: []types.ZarfComponent{
+				{Name: "app", DependsOn: []string{"app"}},
+			},
+			wantErr: "component dependencies cannot be circular: app -> app",
+		},
+		{
+			name: "dependency cycle",
+			components: []types.ZarfComponent{
+				{Name: "app", DependsOn: []string{"database"}},
+				{Name: "database", DependsOn: []string{"storage"}},
+				{Name: "storage", DependsOn: []string{"app"}},
+			},
+			wantErr: "component dependencies cannot be circular: app -> database -> storage -> app",
+		},
+	}
+
+	for _, tt := range tests {
+		err := validateDependencies(tt.components)
+		if tt.wantErr == "" {
+			require.NoError(t, err, tt.name)
+			continue
+		}
+		require.EqualError(t, err, tt.wantErr, tt.name)
+	}
+}
+
+func TestValidatePackageRequirement(t *testing.T) {
+	pkg := types.ZarfPackage{Metadata: types.ZarfMetadata{Name: "app"}}
+
+	tests := []struct {
+		name        string
+		requirement types.ZarfPackageRequirement
+		wantErr     string
+	}{
+		{name: "name only", requirement: types.ZarfPackageRequirement{Name: "database"}},
+		{name: "version constraint", requirement: types.ZarfPackageRequirement{Name: "database", Version: ">= 1.2.0, < 2.0.0"}},
+		{name: "invalid name", requirement: types.ZarfPackageRequirement{Name: "Database"}, wantErr: "Database"},
+		{name: "requires itself", requirement: types.ZarfPackageRequirement{Name: "app"}, wantErr: "package app cannot require itself"},
+		{name: "invalid version constraint", requirement: types.ZarfPackageRequirement{Name: "database", Version: "newest"}, wantErr: "version constraint 'newest' of the required package database is invalid"},
+	}
+
+	for _, tt := range tests {
+		err := validatePackageRequirement(pkg, tt.requirement)
+		if tt.wantErr == "" {
+			require.NoError(t, err, tt.name)
+			continue
+		}
+		require.ErrorContains(t, err, tt.wantErr, tt.name)
+	}
+}
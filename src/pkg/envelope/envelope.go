@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package envelope provides envelope encryption with data keys wrapped by a local key.
+package envelope
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeySize is the size in bytes of the AES-256 keys used for local keys and data keys.
+const KeySize = 32
+
+// KeyProvider wraps and unwraps the data keys that encrypt the data in an envelope.
+type KeyProvider interface {
+	WrapKey(dataKey []byte) ([]byte, error)
+	UnwrapKey(wrappedKey []byte) ([]byte, error)
+}
+
+// Envelope contains data encrypted with a data key and the data key wrapped by a KeyProvider.
+type Envelope struct {
+	WrappedKey []byte `json:"wrappedKey"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Seal encrypts the plaintext with a new data key and wraps the data key with the given provider.
+func Seal(provider KeyProvider, plaintext []byte) (Envelope, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return Envelope{}, fmt.Errorf("unable to generate a data key: %w", err)
+	}
+
+	ciphertext, err := encrypt(dataKey, plaintext)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	wrappedKey, err := provider.WrapKey(dataKey)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("unable to wrap the data key: %w", err)
+	}
+
+	return Envelope{WrappedKey: wrappedKey, Ciphertext: ciphertext}, nil
+}
+
+// Open unwraps the data key of the envelope with the given provider and decrypts its data.
+func Open(provider KeyProvider, env Envelope) ([]byte, error) {
+	dataKey, err := provider.UnwrapKey(env.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unwrap the data key: %w", err)
+	}
+
+	return decrypt(dataKey, env.Ciphertext)
+}
+
+// ParseKey reads a local key that is either KeySize raw bytes or their base64 encoding.
+func ParseKey(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+
+	if decoded, err := base64.StdEncoding.DecodeString(string(trimmed)); err == nil && len(decoded) == KeySize {
+		return decoded, nil
+	}
+
+	if len(data) == KeySize {
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("the key must be %d bytes or their base64 encoding", KeySize)
+}
+
+type localKeyProvider struct {
+	key []byte
+}
+
+// NewLocalKeyProvider returns a KeyProvider that wraps data keys with the given AES-256 key.
+func NewLocalKeyProvider(key []byte) (KeyProvider, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("the key must be %d bytes", KeySize)
+	}
+
+	return &localKeyProvider{key: key}, nil
+}
+
+// WrapKey encrypts the data key with the local key.
+func (p *localKeyProvider) WrapKey(dataKey []byte) ([]byte, error) {
+	return encrypt(p.key, dataKey)
+}
+
+// UnwrapKey decrypts the data key with the local key.
+func (p *localKeyProvider) UnwrapKey(wrappedKey []byte) ([]byte, error) {
+	return decrypt(p.key, wrappedKey)
+}
+
+// encrypt seals the plaintext with AES-GCM and prefixes it with the random nonce.
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("unable to generate a nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decrypt opens a nonce prefixed AES-GCM ciphertext.
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("the ciphertext is too short")
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the data, the key may be wrong: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
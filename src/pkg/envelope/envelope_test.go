@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package envelope provides envelope encryption with data keys wrapped by a local key.
+package envelope
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealAndOpen(t *testing.T) {
+	provider, err := NewLocalKeyProvider(bytes.Repeat([]byte{1}, KeySize))
+	require.NoError(t, err)
+	otherProvider, err := NewLocalKeyProvider(bytes.Repeat([]byte{2}, KeySize))
+	require.NoError(t, err)
+
+	plaintext := []byte(`{"registryInfo":{"pushPassword":"secret"}}`)
+
+	env, err := Seal(provider, plaintext)
+	require.NoError(t, err)
+	require.NotContains(t, string(env.Ciphertext), "secret")
+
+	opened, err := Open(provider, env)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, opened)
+
+	// Every envelope gets its own data key and nonce
+	other, err := Seal(provider, plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, env.WrappedKey, other.WrappedKey)
+	require.NotEqual(t, env.Ciphertext, other.Ciphertext)
+
+	_, err = Open(otherProvider, env)
+	require.ErrorContains(t, err, "unable to unwrap the data key")
+
+	tampered := Envelope{WrappedKey: env.WrappedKey, Ciphertext: append([]byte{}, env.Ciphertext...)}
+	tampered.Ciphertext[len(tampered.Ciphertext)-1] ^= 0xff
+	_, err = Open(provider, tampered)
+	require.ErrorContains(t, err, "unable to decrypt the data")
+
+	_, err = Open(provider, Envelope{WrappedKey: env.WrappedKey, Ciphertext: []byte("short")})
+	require.ErrorContains(t, err, "the ciphertext is too short")
+}
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	_, err := NewLocalKeyProvider(make([]byte, 16))
+	require.ErrorContains(t, err, "the key must be 32 bytes")
+}
+
+func TestParseKey(t *testing.T) {
+	rawKey := bytes.Repeat([]byte{'k'}, KeySize)
+	encodedKey := base64.StdEncoding.EncodeToString(rawKey)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []byte
+		wantErr bool
+	}{
+		{name: "raw key", data: rawKey, want: rawKey},
+		{name: "base64 key", data: []byte(encodedKey), want: rawKey},
+		{name: "base64 key with a trailing newline", data: []byte(encodedKey + "\n"), want: rawKey},
+		{name: "short raw key", data: rawKey[:16], wantErr: true},
+		{name: "short base64 key", data: []byte(base64.StdEncoding.EncodeToString(rawKey[:16])), wantErr: true},
+		{name: "empty key", data: []byte{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		key, err := ParseKey(tt.data)
+		if tt.wantErr {
+			require.Error(t, err, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.want, key, tt.name)
+	}
+}
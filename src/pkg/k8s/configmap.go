@@ -37,6 +37,22 @@ func (k *K8s) CreateConfigmap(namespace, name string, data map[string][]byte) (*
 	return k.Clientset.CoreV1().ConfigMaps(namespace).Create(context.TODO(), configMap, createOptions)
 }
 
+// ApplyConfigmap creates a configmap or updates its data if it already exists.
+func (k *K8s) ApplyConfigmap(namespace, name string, data map[string][]byte) (*corev1.ConfigMap, error) {
+	configMap, err := k.CreateConfigmap(namespace, name, data)
+	if !errors.IsAlreadyExists(err) {
+		return configMap, err
+	}
+
+	configMaps := k.Clientset.CoreV1().ConfigMaps(namespace)
+	if configMap, err = configMaps.Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	configMap.BinaryData = data
+	return configMaps.Update(context.TODO(), configMap, metav1.UpdateOptions{})
+}
+
 // DeleteConfigmap deletes a configmap by name.
 func (k *K8s) DeleteConfigmap(namespace, name string) error {
 	namespaceConfigmap := k.Clientset.CoreV1().ConfigMaps(namespace)
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// GetCustomResourceDefinition returns a Kubernetes custom resource definition.
+func (k *K8s) GetCustomResourceDefinition(name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	clientset, err := apiextensions.NewForConfig(k.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// ApplyCustomResourceDefinition creates the given custom resource definition or updates it if it already exists.
+func (k *K8s) ApplyCustomResourceDefinition(crd *apiextensionsv1.CustomResourceDefinition) error {
+	clientset, err := apiextensions.NewForConfig(k.RestConfig)
+	if err != nil {
+		return err
+	}
+
+	crds := clientset.ApiextensionsV1().CustomResourceDefinitions()
+
+	existing, err := crds.Get(context.TODO(), crd.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = crds.Create(context.TODO(), crd, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	crd.ResourceVersion = existing.ResourceVersion
+	_, err = crds.Update(context.TODO(), crd, metav1.UpdateOptions{})
+	return err
+}
+
+// DeleteCustomResourceDefinition deletes a Kubernetes custom resource definition and all of its resources.
+func (k *K8s) DeleteCustomResourceDefinition(name string) error {
+	clientset, err := apiextensions.NewForConfig(k.RestConfig)
+	if err != nil {
+		return err
+	}
+
+	err = clientset.ApiextensionsV1().CustomResourceDefinitions().Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// WaitForCustomResourceDefinition waits until a custom resource definition is established and can be used.
+func (k *K8s) WaitForCustomResourceDefinition(name string, timeout time.Duration) error {
+	expired := time.After(timeout)
+
+	for {
+		select {
+		case <-expired:
+			return fmt.Errorf("timed out waiting for the custom resource definition %s to be established", name)
+
+		case <-time.After(time.Second):
+			crd, err := k.GetCustomResourceDefinition(name)
+			if err != nil {
+				k.Log("Unable to get the custom resource definition %s: %s", name, err.Error())
+				continue
+			}
+
+			for _, condition := range crd.Status.Conditions {
+				if condition.Type == apiextensionsv1.Established && condition.Status == apiextensionsv1.ConditionTrue {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// GetCustomResource returns a custom resource in the provided namespace with the given name.
+func (k *K8s) GetCustomResource(resource schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	client, err := dynamic.NewForConfig(k.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Resource(resource).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// GetCustomResources returns the custom resources in the provided namespace.
+func (k *K8s) GetCustomResources(resource schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+	client, err := dynamic.NewForConfig(k.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Resource(resource).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+}
+
+// ApplyCustomResource creates the given custom resource or replaces it if it already exists.
+func (k *K8s) ApplyCustomResource(resource schema.GroupVersionResource, object *unstructured.Unstructured) error {
+	client, err := dynamic.NewForConfig(k.RestConfig)
+	if err != nil {
+		return err
+	}
+
+	resources := client.Resource(resource).Namespace(object.GetNamespace())
+
+	existing, err := resources.Get(context.TODO(), object.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = resources.Create(context.TODO(), object, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	object.SetResourceVersion(existing.GetResourceVersion())
+	_, err = resources.Update(context.TODO(), object, metav1.UpdateOptions{})
+	return err
+}
+
+// DeleteCustomResource deletes a custom resource in the provided namespace with the given name.
+func (k *K8s) DeleteCustomResource(resource schema.GroupVersionResource, namespace, name string) error {
+	client, err := dynamic.NewForConfig(k.RestConfig)
+	if err != nil {
+		return err
+	}
+
+	err = client.Resource(resource).Namespace(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDeployment returns a Kubernetes deployment in the provided namespace with the given name.
+func (k *K8s) GetDeployment(namespace, name string) (*appsv1.Deployment, error) {
+	return k.Clientset.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// RestartDeployment triggers a rollout of new pods for a deployment, the same way `kubectl rollout restart` does.
+func (k *K8s) RestartDeployment(namespace, name string) error {
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%s"}}}}}`,
+		time.Now().Format(time.RFC3339))
+
+	_, err := k.Clientset.AppsV1().Deployments(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+// WaitForDeploymentRollout waits until every replica of a deployment has been updated and is available.
+func (k *K8s) WaitForDeploymentRollout(namespace, name string, timeout time.Duration) error {
+	expired := time.After(timeout)
+
+	for {
+		select {
+		case <-expired:
+			return fmt.Errorf("timed out waiting for the deployment %s/%s to roll out", namespace, name)
+
+		case <-time.After(2 * time.Second):
+			deployment, err := k.GetDeployment(namespace, name)
+			if err != nil {
+				k.Log("Unable to get the deployment %s/%s: %s", namespace, name, err.Error())
+				continue
+			}
+
+			replicas := int32(1)
+			if deployment.Spec.Replicas != nil {
+				replicas = *deployment.Spec.Replicas
+			}
+
+			status := deployment.Status
+			if status.ObservedGeneration >= deployment.Generation && status.UpdatedReplicas == replicas &&
+				status.AvailableReplicas == replicas && status.Replicas == replicas {
+				return nil
+			}
+		}
+	}
+}
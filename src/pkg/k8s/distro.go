@@ -6,7 +6,9 @@ package k8s
 
 import (
 	"errors"
+	"path"
 	"regexp"
+	"strings"
 )
 
 // List of supported distros via distro detection.
@@ -25,6 +27,58 @@ const (
 	DistroIsTKG           = "tkg"
 )
 
+// Distros are the distros that DetectDistro can return.
+var Distros = []string{
+	DistroIsK3s,
+	DistroIsK3d,
+	DistroIsKind,
+	DistroIsMicroK8s,
+	DistroIsEKS,
+	DistroIsEKSAnywhere,
+	DistroIsDockerDesktop,
+	DistroIsGKE,
+	DistroIsAKS,
+	DistroIsRKE2,
+	DistroIsTKG,
+	DistroIsUnknown,
+}
+
+// MatchesDistro returns true if the distro is selected by the given patterns. Patterns may contain shell wildcards
+// (e.g. "eks*") and patterns prefixed with "!" exclude the distros they match. A list with only exclusions selects
+// every other distro and an empty list selects every distro.
+func MatchesDistro(patterns []string, distro string) bool {
+	var included, hasInclusions bool
+
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			if matched, _ := path.Match(strings.TrimPrefix(pattern, "!"), distro); matched {
+				return false
+			}
+			continue
+		}
+
+		hasInclusions = true
+		if matched, _ := path.Match(pattern, distro); matched {
+			included = true
+		}
+	}
+
+	return included || !hasInclusions
+}
+
+// IsValidDistroPattern returns true if the pattern (without its "!" prefix) matches at least one of the known distros.
+func IsValidDistroPattern(pattern string) bool {
+	pattern = strings.TrimPrefix(pattern, "!")
+
+	for _, distro := range Distros {
+		if matched, err := path.Match(pattern, distro); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 // DetectDistro returns the matching distro or unknown if not found.
 func (k *K8s) DetectDistro() (string, error) {
 	kindNodeRegex := regexp.MustCompile(`^kind://`)
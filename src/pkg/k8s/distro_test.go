@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesDistro(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		distro   string
+		want     bool
+	}{
+		{name: "no patterns", distro: DistroIsK3s, want: true},
+		{name: "exact match", patterns: []string{DistroIsK3s}, distro: DistroIsK3s, want: true},
+		{name: "no match", patterns: []string{DistroIsK3s}, distro: DistroIsKind, want: false},
+		{name: "wildcard match", patterns: []string{"eks*"}, distro: DistroIsEKSAnywhere, want: true},
+		{name: "one of several", patterns: []string{DistroIsGKE, DistroIsAKS}, distro: DistroIsAKS, want: true},
+		{name: "only exclusions", patterns: []string{"!eks*", "!gke"}, distro: DistroIsKind, want: true},
+		{name: "excluded", patterns: []string{"!eks*", "!gke"}, distro: DistroIsEKS, want: false},
+		{name: "exclusion wins over inclusion", patterns: []string{"k3*", "!k3d"}, distro: DistroIsK3d, want: false},
+		{name: "inclusion next to exclusion", patterns: []string{"k3*", "!k3d"}, distro: DistroIsK3s, want: true},
+		{name: "unknown distro", patterns: []string{DistroIsK3s}, distro: DistroIsUnknown, want: false},
+		{name: "malformed pattern", patterns: []string{"[k3s"}, distro: DistroIsK3s, want: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, MatchesDistro(tt.patterns, tt.distro), tt.name)
+	}
+}
+
+func TestIsValidDistroPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{pattern: DistroIsK3s, want: true},
+		{pattern: "!gke", want: true},
+		{pattern: "eks*", want: true},
+		{pattern: "*", want: true},
+		{pattern: "openshift", want: false},
+		{pattern: "!openshift*", want: false},
+		{pattern: "[k3s", want: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, IsValidDistroPattern(tt.pattern), tt.pattern)
+	}
+}
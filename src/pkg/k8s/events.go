@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateEvent records an event of the given type and reason about the given object.
+func (k *K8s) CreateEvent(object corev1.ObjectReference, component, eventType, reason, msg string) (*corev1.Event, error) {
+	now := metav1.NewTime(time.Now())
+
+	event := &corev1.Event{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Event",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			// Follow the kubectl convention of prefixing the event name with the object name
+			GenerateName: object.Name + ".",
+			Namespace:    object.Namespace,
+			Labels:       k.Labels,
+		},
+		InvolvedObject: object,
+		Reason:         reason,
+		Message:        msg,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: component},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	metaOptions := metav1.CreateOptions{}
+	return k.Clientset.CoreV1().Events(object.Namespace).Create(context.TODO(), event, metaOptions)
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// cancelableUpgrader closes the SPDY connections it creates when the context is done, which interrupts any stream
+// still running on them (client-go only has StreamWithContext from v0.26).
+type cancelableUpgrader struct {
+	spdy.Upgrader
+	ctx context.Context
+}
+
+// NewConnection creates the SPDY connection and closes it as soon as the context is done.
+func (u cancelableUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		select {
+		case <-u.ctx.Done():
+			conn.Close()
+		case <-conn.CloseChan():
+		}
+	}()
+
+	return conn, nil
+}
+
+// ExecInPod runs a command in a container of a pod over a SPDY stream, piping stdin (if not nil) to the command. The
+// stream is closed if the context is done before the command exits.
+func (k *K8s) ExecInPod(ctx context.Context, namespace, pod, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	k.Log("k8s.ExecInPod(%s, %s, %s, %v)", namespace, pod, container, command)
+
+	req := k.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+		}, scheme.ParameterCodec)
+
+	transport, upgrader, err := spdy.RoundTripperFor(k.RestConfig)
+	if err != nil {
+		return fmt.Errorf("unable to create the exec transport: %w", err)
+	}
+
+	exec, err := remotecommand.NewSPDYExecutorForTransports(transport, cancelableUpgrader{upgrader, ctx}, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("unable to create the exec stream: %w", err)
+	}
+
+	err = exec.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	// Report the cancellation rather than the error of the closed stream
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	return err
+}
+
+// CopyToPod streams a tar archive of srcPath into destPath of a container, creating destPath if needed. If srcPath is
+// a directory its contents are copied, otherwise the single file is. The container must provide tar (and gzip if
+// compress is set). The bytes of file content read are written to progress if it is not nil. The copy is interrupted
+// if the context is done.
+func (k *K8s) CopyToPod(ctx context.Context, namespace, pod, container, srcPath, destPath string, compress bool, progress io.Writer) error {
+	k.Log("k8s.CopyToPod(%s, %s, %s, %s, %s, %t)", namespace, pod, container, srcPath, destPath, compress)
+
+	var stderr bytes.Buffer
+
+	mkdirCmd := []string{"mkdir", "-p", destPath}
+	if err := k.ExecInPod(ctx, namespace, pod, container, mkdirCmd, nil, nil, &stderr); err != nil {
+		return fmt.Errorf("unable to create %s in pod %s: %w: %s", destPath, pod, err, strings.TrimSpace(stderr.String()))
+	}
+
+	untarFlags := "xf"
+	if compress {
+		untarFlags = "xzf"
+	}
+	untarCmd := []string{"tar", untarFlags, "-", "-C", destPath}
+
+	reader, writer := io.Pipe()
+	go func() {
+		// Closing with a nil error signals EOF to the reader
+		writer.CloseWithError(writeTar(writer, srcPath, compress, progress))
+	}()
+	// Unblock the tar writer if the exec fails before reading everything
+	defer reader.Close()
+
+	stderr.Reset()
+	if err := k.ExecInPod(ctx, namespace, pod, container, untarCmd, reader, nil, &stderr); err != nil {
+		return fmt.Errorf("unable to copy %s into pod %s: %w: %s", srcPath, pod, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// writeTar writes a tar archive of srcPath to w, optionally gzip compressed.
+func writeTar(w io.Writer, srcPath string, compress bool, progress io.Writer) error {
+	var gzipWriter *gzip.Writer
+	if compress {
+		gzipWriter = gzip.NewWriter(w)
+		w = gzipWriter
+	}
+
+	tarWriter := tar.NewWriter(w)
+
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+
+	// Copy the contents of directories and the file itself otherwise
+	baseDir := srcPath
+	if !info.IsDir() {
+		baseDir = filepath.Dir(srcPath)
+	}
+
+	err = filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		if relativePath == "." {
+			return nil
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relativePath)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		var reader io.Reader = file
+		if progress != nil {
+			reader = io.TeeReader(file, progress)
+		}
+
+		_, err = io.Copy(tarWriter, reader)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Closing writes the archive footers, so it must succeed for the archive to be complete
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if gzipWriter != nil {
+		return gzipWriter.Close()
+	}
+
+	return nil
+}
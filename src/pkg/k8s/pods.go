@@ -75,9 +75,14 @@ func (k *K8s) GetPods(namespace string) (*corev1.PodList, error) {
 // It will wait up to 90 seconds for the pods to be found and will return a list of matching pod names
 // If the timeout is reached, an empty list will be returned.
 func (k *K8s) WaitForPodsAndContainers(target PodLookup, include PodFilter) []string {
+	return k.WaitForPodsAndContainersWithContext(context.TODO(), target, include)
+}
+
+// WaitForPodsAndContainersWithContext is WaitForPodsAndContainers that also stops waiting when the context is done.
+func (k *K8s) WaitForPodsAndContainersWithContext(ctx context.Context, target PodLookup, include PodFilter) []string {
 	for count := 0; count < waitLimit; count++ {
 
-		pods, err := k.Clientset.CoreV1().Pods(target.Namespace).List(context.TODO(), metav1.ListOptions{
+		pods, err := k.Clientset.CoreV1().Pods(target.Namespace).List(ctx, metav1.ListOptions{
 			LabelSelector: target.Selector,
 		})
 		if err != nil {
@@ -148,7 +153,12 @@ func (k *K8s) WaitForPodsAndContainers(target PodLookup, include PodFilter) []st
 			}
 		}
 
-		time.Sleep(3 * time.Second)
+		select {
+		case <-ctx.Done():
+			k.Log("Pod lookup canceled: %s", ctx.Err())
+			return []string{}
+		case <-time.After(3 * time.Second):
+		}
 	}
 
 	k.Log("Pod lookup timeout exceeded")
@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
 // GetSecret returns a Kubernetes secret.
@@ -25,6 +26,12 @@ func (k *K8s) GetSecretsWithLabel(namespace, labelSelector string) (*corev1.Secr
 	return k.Clientset.CoreV1().Secrets(namespace).List(context.TODO(), listOptions)
 }
 
+// GetSecretsWithName returns the secrets with the given name in every namespace.
+func (k *K8s) GetSecretsWithName(name string) (*corev1.SecretList, error) {
+	listOptions := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String()}
+	return k.Clientset.CoreV1().Secrets(corev1.NamespaceAll).List(context.TODO(), listOptions)
+}
+
 // GenerateSecret returns a Kubernetes secret object without applying it to the cluster.
 func (k *K8s) GenerateSecret(namespace, name string, secretType corev1.SecretType) *corev1.Secret {
 	return &corev1.Secret{
@@ -78,6 +85,11 @@ func (k *K8s) ReplaceSecret(secret *corev1.Secret) error {
 	return k.CreateSecret(secret)
 }
 
+// UpdateSecret updates the given Kubernetes secret in place, keeping the labels and annotations of its owner.
+func (k *K8s) UpdateSecret(secret *corev1.Secret) (*corev1.Secret, error) {
+	return k.Clientset.CoreV1().Secrets(secret.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+}
+
 // DeleteSecret deletes a Kubernetes secret.
 func (k *K8s) DeleteSecret(secret *corev1.Secret) error {
 	namespaceSecrets := k.Clientset.CoreV1().Secrets(secret.Namespace)
@@ -34,6 +34,17 @@ type PodLookup struct {
 // PodFilter is a function that returns true if the pod should be targeted for data injection or lookups.
 type PodFilter func(pod corev1.Pod) bool
 
+// WaitCondition is a struct for specifying the resources and the state to wait for.
+type WaitCondition struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Selector  string
+	Condition string
+	JSONPath  string
+	Value     string
+}
+
 // GeneratedPKI is a struct for storing generated PKI data.
 type GeneratedPKI struct {
 	CA   []byte `json:"ca"`
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ParseJSONPath parses a JSONPath expression, the surrounding braces are optional (i.e. .status.phase or {.status.phase}).
+func ParseJSONPath(expression string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+
+	parser := jsonpath.New("wait").AllowMissingKeys(true)
+	if err := parser.Parse(expression); err != nil {
+		return nil, err
+	}
+
+	return parser, nil
+}
+
+// workloadConditions are the status condition types that built-in workload kinds can have, keyed by the lowercase
+// kind, its plural and its short name. StatefulSets and DaemonSets have no conditions to wait on.
+var workloadConditions = map[string][]string{
+	"deployment":   {"Available", "Progressing", "ReplicaFailure"},
+	"deployments":  {"Available", "Progressing", "ReplicaFailure"},
+	"deploy":       {"Available", "Progressing", "ReplicaFailure"},
+	"replicaset":   {"ReplicaFailure"},
+	"replicasets":  {"ReplicaFailure"},
+	"rs":           {"ReplicaFailure"},
+	"statefulset":  {},
+	"statefulsets": {},
+	"sts":          {},
+	"daemonset":    {},
+	"daemonsets":   {},
+	"ds":           {},
+	"job":          {"Complete", "Failed", "Suspended"},
+	"jobs":         {"Complete", "Failed", "Suspended"},
+}
+
+// ValidConditions returns the condition types the given kind can have and whether they are known, they are only known
+// for built-in workload kinds (i.e. a Deployment never has a Ready condition).
+func ValidConditions(kind string) ([]string, bool) {
+	kind, _, _ = strings.Cut(strings.ToLower(kind), ".")
+	conditions, ok := workloadConditions[kind]
+	return conditions, ok
+}
+
+// String returns a short description of the resources targeted by the wait condition.
+func (w WaitCondition) String() string {
+	target := w.Kind
+	if w.Name != "" {
+		target = fmt.Sprintf("%s/%s", w.Kind, w.Name)
+	} else if w.Selector != "" {
+		target = fmt.Sprintf("%s (%s)", w.Kind, w.Selector)
+	}
+
+	if w.Namespace != "" {
+		target = fmt.Sprintf("%s in namespace %s", target, w.Namespace)
+	}
+
+	return target
+}
+
+// WaitForCondition polls the cluster until all resources targeted by the wait condition exist and are in the expected
+// state, the progress function is called with the current status of the resources after every check. Errors from the
+// API server are retried until the timeout.
+func (k *K8s) WaitForCondition(target WaitCondition, timeout time.Duration, progress func(status string)) error {
+	client, err := dynamic.NewForConfig(k.RestConfig)
+	if err != nil {
+		return err
+	}
+
+	var jsonPath *jsonpath.JSONPath
+	if target.JSONPath != "" {
+		if jsonPath, err = ParseJSONPath(target.JSONPath); err != nil {
+			return fmt.Errorf("unable to parse the jsonPath %s: %w", target.JSONPath, err)
+		}
+	}
+
+	var resources dynamic.ResourceInterface
+	expired := time.After(timeout)
+	for {
+		var status string
+
+		// The kind may come from a CRD that was only just created, so keep trying to find it until it is known
+		if resources == nil {
+			resource, namespaced, err := k.findResource(target.Kind)
+			if err != nil {
+				status = fmt.Sprintf("Waiting for the %s kind to be available in the cluster", target.Kind)
+			} else if namespaced {
+				// Like kubectl, a named resource without a namespace is in the default namespace while a selector
+				// without a namespace matches resources in all namespaces
+				if target.Namespace == "" && target.Name != "" {
+					target.Namespace = metav1.NamespaceDefault
+				}
+				resources = client.Resource(resource).Namespace(target.Namespace)
+			} else {
+				resources = client.Resource(resource)
+			}
+		}
+
+		if resources != nil {
+			var ready bool
+			if ready, status, err = checkResources(resources, target, jsonPath); err != nil {
+				// The API server may be briefly unavailable (i.e. while nodes or webhooks restart), so keep trying
+				k.Log("Unable to check %s: %s", target, err.Error())
+				status = err.Error()
+			} else if ready {
+				return nil
+			}
+		}
+
+		progress(status)
+
+		select {
+		case <-expired:
+			return fmt.Errorf("timed out after %s waiting for %s: %s", timeout, target, status)
+		case <-time.After(3 * time.Second):
+		}
+	}
+}
+
+// findResource returns the resource for the given kind (i.e. Deployment, deployments or deployments.apps) and whether
+// it is namespaced.
+func (k *K8s) findResource(kind string) (schema.GroupVersionResource, bool, error) {
+	discovery := memory.NewMemCacheClient(k.Clientset.Discovery())
+	mapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(discovery), discovery)
+
+	resourceArg, groupResource := schema.ParseResourceArg(strings.ToLower(kind))
+	resource := groupResource.WithVersion("")
+	if resourceArg != nil {
+		resource = *resourceArg
+	}
+
+	resource, err := mapper.ResourceFor(resource)
+	if err != nil {
+		return resource, false, err
+	}
+
+	gvk, err := mapper.KindFor(resource)
+	if err != nil {
+		return resource, false, err
+	}
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return resource, false, err
+	}
+
+	return resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// checkResources returns whether all resources targeted by the wait condition are ready, along with a status message
+// describing the first resource that is not.
+func checkResources(resources dynamic.ResourceInterface, target WaitCondition, jsonPath *jsonpath.JSONPath) (bool, string, error) {
+	var objects []unstructured.Unstructured
+
+	if target.Name != "" {
+		object, err := resources.Get(context.TODO(), target.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return false, fmt.Sprintf("Waiting for %s to be created", target), nil
+		} else if err != nil {
+			return false, "", fmt.Errorf("unable to get %s: %w", target, err)
+		}
+		objects = append(objects, *object)
+	} else {
+		list, err := resources.List(context.TODO(), metav1.ListOptions{LabelSelector: target.Selector})
+		if err != nil {
+			return false, "", fmt.Errorf("unable to list %s: %w", target, err)
+		}
+		if len(list.Items) < 1 {
+			return false, fmt.Sprintf("Waiting for %s to be created", target), nil
+		}
+		objects = list.Items
+	}
+
+	for _, object := range objects {
+		name := fmt.Sprintf("%s/%s", target.Kind, object.GetName())
+
+		// The status still describes the previous spec until the controller has observed the latest generation
+		if observed, found, _ := unstructured.NestedInt64(object.Object, "status", "observedGeneration"); found && observed < object.GetGeneration() {
+			return false, fmt.Sprintf("Waiting for %s to observe generation %d (currently %d)", name, object.GetGeneration(), observed), nil
+		}
+
+		if target.Condition != "" && !hasCondition(object, target.Condition) {
+			return false, fmt.Sprintf("Waiting for %s to be %s", name, target.Condition), nil
+		}
+
+		if jsonPath != nil {
+			var value bytes.Buffer
+			if err := jsonPath.Execute(&value, object.Object); err != nil {
+				return false, "", fmt.Errorf("unable to evaluate the jsonPath %s against %s: %w", target.JSONPath, name, err)
+			}
+
+			if value.String() != target.Value {
+				return false, fmt.Sprintf("Waiting for %s of %s to be %q (currently %q)", target.JSONPath, name, target.Value, value.String()), nil
+			}
+		}
+	}
+
+	return true, "", nil
+}
+
+// hasCondition returns true if the status conditions of the object contain the given condition type with a True status.
+func hasCondition(object unstructured.Unstructured, condition string) bool {
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	for _, c := range conditions {
+		if c, ok := c.(map[string]any); ok {
+			conditionType, _ := c["type"].(string)
+			conditionStatus, _ := c["status"].(string)
+			if strings.EqualFold(conditionType, condition) && strings.EqualFold(conditionStatus, "True") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
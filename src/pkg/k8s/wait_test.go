@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	object := map[string]any{"status": map[string]any{"phase": "Running"}}
+
+	tests := []struct {
+		expression string
+		want       string
+		wantErr    bool
+	}{
+		{expression: ".status.phase", want: "Running"},
+		{expression: "{.status.phase}", want: "Running"},
+		{expression: ".status.missing", want: ""},
+		{expression: "{.status.phase", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		parser, err := ParseJSONPath(tt.expression)
+		if tt.wantErr {
+			require.Error(t, err, tt.expression)
+			continue
+		}
+		require.NoError(t, err, tt.expression)
+
+		var value bytes.Buffer
+		require.NoError(t, parser.Execute(&value, object), tt.expression)
+		require.Equal(t, tt.want, value.String(), tt.expression)
+	}
+}
+
+func TestValidConditions(t *testing.T) {
+	tests := []struct {
+		kind      string
+		condition string
+		known     bool
+		valid     bool
+	}{
+		{kind: "Deployment", condition: "Available", known: true, valid: true},
+		{kind: "deployments.apps", condition: "Available", known: true, valid: true},
+		{kind: "deploy", condition: "Ready", known: true, valid: false},
+		{kind: "StatefulSet", condition: "Ready", known: true, valid: false},
+		{kind: "Job", condition: "Complete", known: true, valid: true},
+		{kind: "Pod", condition: "Ready", known: false},
+		{kind: "HelmRelease", condition: "Ready", known: false},
+	}
+
+	for _, tt := range tests {
+		conditions, known := ValidConditions(tt.kind)
+		require.Equal(t, tt.known, known, tt.kind)
+		if known {
+			require.Equal(t, tt.valid, contains(conditions, tt.condition), "%s %s", tt.kind, tt.condition)
+		}
+	}
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func newWaitTestObject(name string, generation, observedGeneration int64, available string, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":       name,
+			"namespace":  "podinfo",
+			"generation": generation,
+			"labels":     map[string]any{"app": "podinfo"},
+		},
+		"status": map[string]any{
+			"observedGeneration": observedGeneration,
+			"readyReplicas":      replicas,
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": available},
+			},
+		},
+	}}
+}
+
+func TestCheckResources(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	readyReplicas, err := ParseJSONPath(".status.readyReplicas")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		target     WaitCondition
+		jsonPath   *jsonpath.JSONPath
+		wantReady  bool
+		wantStatus string
+	}{
+		{
+			name:       "missing by name",
+			target:     WaitCondition{Kind: "Deployment", Name: "podinfo", Namespace: "podinfo", Condition: "Available"},
+			wantStatus: "to be created",
+		},
+		{
+			name:       "missing by selector",
+			target:     WaitCondition{Kind: "Deployment", Selector: "app=podinfo", Namespace: "podinfo", Condition: "Available"},
+			wantStatus: "to be created",
+		},
+		{
+			name:      "condition met",
+			objects:   []runtime.Object{newWaitTestObject("podinfo", 2, 2, "True", 1)},
+			target:    WaitCondition{Kind: "Deployment", Name: "podinfo", Namespace: "podinfo", Condition: "available"},
+			wantReady: true,
+		},
+		{
+			name:       "condition not met",
+			objects:    []runtime.Object{newWaitTestObject("podinfo", 2, 2, "False", 1)},
+			target:     WaitCondition{Kind: "Deployment", Name: "podinfo", Namespace: "podinfo", Condition: "Available"},
+			wantStatus: "Waiting for Deployment/podinfo to be Available",
+		},
+		{
+			name:       "condition from a previous generation",
+			objects:    []runtime.Object{newWaitTestObject("podinfo", 3, 2, "True", 1)},
+			target:     WaitCondition{Kind: "Deployment", Name: "podinfo", Namespace: "podinfo", Condition: "Available"},
+			wantStatus: "to observe generation 3 (currently 2)",
+		},
+		{
+			name:      "jsonPath met by every selected resource",
+			objects:   []runtime.Object{newWaitTestObject("first", 1, 1, "True", 1), newWaitTestObject("second", 1, 1, "True", 1)},
+			target:    WaitCondition{Kind: "Deployment", Selector: "app=podinfo", Namespace: "podinfo", JSONPath: ".status.readyReplicas", Value: "1"},
+			jsonPath:  readyReplicas,
+			wantReady: true,
+		},
+		{
+			name:       "jsonPath not met by one selected resource",
+			objects:    []runtime.Object{newWaitTestObject("first", 1, 1, "True", 1), newWaitTestObject("second", 1, 1, "True", 0)},
+			target:     WaitCondition{Kind: "Deployment", Selector: "app=podinfo", Namespace: "podinfo", JSONPath: ".status.readyReplicas", Value: "1"},
+			jsonPath:   readyReplicas,
+			wantStatus: `of Deployment/second to be "1" (currently "0")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{deployments: "DeploymentList"}, tt.objects...)
+
+			ready, status, err := checkResources(client.Resource(deployments).Namespace("podinfo"), tt.target, tt.jsonPath)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantReady, ready)
+			require.Contains(t, status, tt.wantStatus)
+		})
+	}
+}
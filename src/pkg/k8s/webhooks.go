@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package k8s provides a client for interacting with a Kubernetes cluster.
+package k8s
+
+import (
+	"context"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetMutatingWebhookConfiguration returns a mutating webhook configuration by name.
+func (k *K8s) GetMutatingWebhookConfiguration(name string) (*admissionv1.MutatingWebhookConfiguration, error) {
+	return k.Clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// UpdateMutatingWebhookConfiguration updates the given mutating webhook configuration in the cluster.
+func (k *K8s) UpdateMutatingWebhookConfiguration(webhookConfig *admissionv1.MutatingWebhookConfiguration) (*admissionv1.MutatingWebhookConfiguration, error) {
+	return k.Clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(context.TODO(), webhookConfig, metav1.UpdateOptions{})
+}
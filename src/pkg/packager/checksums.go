@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/packager/images"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// imageChecksumPrefix marks checksum entries of individual images within the images tarball, images are checked by
+// their manifest digest since the tarball itself is rebuilt when a package is pulled from an OCI registry.
+const imageChecksumPrefix = "images.tar/"
+
+// generateChecksums writes a checksums.txt listing the sha256 of every file in the package and returns its sha256.
+func (p *Packager) generateChecksums() (string, error) {
+	message.Debug("packager.generateChecksums()")
+
+	checksums, err := p.getChecksums()
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for path, sum := range checksums {
+		lines = append(lines, fmt.Sprintf("%s %s", sum, path))
+	}
+	sort.Strings(lines)
+
+	checksumsPath := filepath.Join(p.tmp.Base, config.ZarfChecksumsTxt)
+	if err := os.WriteFile(checksumsPath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("unable to write %s: %w", config.ZarfChecksumsTxt, err)
+	}
+
+	return utils.GetSha256Sum(checksumsPath)
+}
+
+// addChecksums writes the checksums.txt for the package and records its sha256 in the zarf.yaml build data.
+func (p *Packager) addChecksums() error {
+	message.Debug("packager.addChecksums()")
+
+	aggregateChecksum, err := p.generateChecksums()
+	if err != nil {
+		return fmt.Errorf("unable to generate the package checksums: %w", err)
+	}
+
+	// The zarf.yaml is written read-only, so replace it rather than writing over it
+	p.cfg.Pkg.Build.AggregateChecksum = aggregateChecksum
+	if err := os.Remove(p.tmp.ZarfYaml); err != nil {
+		return fmt.Errorf("unable to update the zarf.yaml: %w", err)
+	}
+
+	return utils.WriteYaml(p.tmp.ZarfYaml, p.cfg.Pkg, 0400)
+}
+
+// validateChecksums verifies that checksums.txt matches the aggregate checksum in the zarf.yaml and that every file in
+// the package matches checksums.txt, reporting the damaged files of each component. Only the components and images
+// that were deliberately not pulled from an OCI package are allowed to be missing.
+func (p *Packager) validateChecksums() error {
+	message.Debug("packager.validateChecksums()")
+
+	checksumsPath := filepath.Join(p.tmp.Base, config.ZarfChecksumsTxt)
+
+	if p.cfg.Pkg.Build.AggregateChecksum == "" {
+		return fmt.Errorf("the package does not contain an aggregate checksum")
+	}
+
+	aggregateChecksum, err := utils.GetSha256Sum(checksumsPath)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", config.ZarfChecksumsTxt, err)
+	}
+
+	if aggregateChecksum != p.cfg.Pkg.Build.AggregateChecksum {
+		return fmt.Errorf("%s does not match the aggregate checksum, expected %s, got %s", config.ZarfChecksumsTxt, p.cfg.Pkg.Build.AggregateChecksum, aggregateChecksum)
+	}
+
+	expected, err := readChecksums(checksumsPath)
+	if err != nil {
+		return err
+	}
+
+	actual, err := p.getChecksums()
+	if err != nil {
+		return err
+	}
+
+	// Group the damaged files by the component they belong to so the report points at what will fail to deploy
+	damaged := map[string][]string{}
+	addDamaged := func(path string, reason string) {
+		group := path
+		if parts := strings.SplitN(path, "/", 3); len(parts) == 3 && parts[0] == "components" {
+			group = fmt.Sprintf("component %q", parts[1])
+			path = parts[2]
+		}
+		damaged[group] = append(damaged[group], fmt.Sprintf("%s (%s)", path, reason))
+	}
+
+	for path, sum := range expected {
+		actualSum, ok := actual[path]
+		switch {
+		case !ok && p.isUnpulled(path):
+			continue
+		case !ok:
+			addDamaged(path, "missing")
+		case actualSum != sum:
+			addDamaged(path, fmt.Sprintf("expected %s, got %s", sum, actualSum))
+		}
+	}
+
+	for path := range actual {
+		if _, ok := expected[path]; !ok {
+			addDamaged(path, fmt.Sprintf("not listed in %s", config.ZarfChecksumsTxt))
+		}
+	}
+
+	if len(damaged) > 0 {
+		var failures []string
+		for group, files := range damaged {
+			sort.Strings(files)
+			if strings.HasPrefix(group, "component ") {
+				failures = append(failures, fmt.Sprintf("%s: %s", group, strings.Join(files, ", ")))
+			} else {
+				failures = append(failures, files...)
+			}
+		}
+		sort.Strings(failures)
+		return fmt.Errorf("package contents do not match %s:\n- %s", config.ZarfChecksumsTxt, strings.Join(failures, "\n- "))
+	}
+
+	return nil
+}
+
+// isUnpulled returns true if the checksum entry belongs to a component or image that was deliberately not pulled.
+func (p *Packager) isUnpulled(path string) bool {
+	if strings.HasPrefix(path, "components/") {
+		component, _, _ := strings.Cut(strings.TrimPrefix(path, "components/"), "/")
+		return p.unpulledComponents[component]
+	}
+
+	if strings.HasPrefix(path, imageChecksumPrefix) {
+		tag, err := name.NewTag(strings.TrimPrefix(path, imageChecksumPrefix))
+		return err == nil && p.unpulledImages[tag.Name()]
+	}
+
+	return false
+}
+
+// getChecksums returns the sha256 of every file in the package keyed by its relative path, excluding the files that
+// make up the package signature. Images are listed individually by manifest digest instead of the images tarball.
+func (p *Packager) getChecksums() (map[string]string, error) {
+	checksums := map[string]string{}
+
+	skip := map[string]bool{
+		p.tmp.ZarfYaml: true,
+		filepath.Join(p.tmp.Base, config.ZarfYAMLSignature): true,
+		filepath.Join(p.tmp.Base, config.ZarfChecksumsTxt):  true,
+		p.tmp.Images: true,
+		// Remote packages are downloaded into the temp directory
+		p.cfg.DeployOpts.PackagePath: true,
+	}
+
+	err := filepath.WalkDir(p.tmp.Base, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || skip[path] {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(p.tmp.Base, path)
+		if err != nil {
+			return err
+		}
+
+		sum, err := utils.GetSha256Sum(path)
+		if err != nil {
+			return err
+		}
+
+		checksums[filepath.ToSlash(relativePath)] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute the package checksums: %w", err)
+	}
+
+	if !utils.InvalidPath(p.tmp.Images) {
+		digests, err := images.GetTarballImageDigests(p.tmp.Images)
+		if err != nil {
+			return nil, err
+		}
+
+		for tag, digest := range digests {
+			checksums[imageChecksumPrefix+tag] = digest
+		}
+	}
+
+	return checksums, nil
+}
+
+// readChecksums parses a checksums.txt into a map of relative paths to sha256 sums.
+func readChecksums(checksumsPath string) (map[string]string, error) {
+	file, err := os.Open(checksumsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s: %w", config.ZarfChecksumsTxt, err)
+	}
+	defer file.Close()
+
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		sum, path, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid line in %s: %q", config.ZarfChecksumsTxt, line)
+		}
+		checksums[path] = sum
+	}
+
+	return checksums, scanner.Err()
+}
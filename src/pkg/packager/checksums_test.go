@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newChecksumTestPackager(t *testing.T) *Packager {
+	base := t.TempDir()
+	p := &Packager{
+		cfg: &types.PackagerConfig{},
+		tmp: types.TempPaths{
+			Base:     base,
+			Images:   filepath.Join(base, "images.tar"),
+			ZarfYaml: filepath.Join(base, config.ZarfYAML),
+		},
+	}
+
+	files := map[string]string{
+		"components/first/manifests/deployment.yaml": "first",
+		"components/second/files/0":                  "second",
+		"sboms/first.json":                           "sbom",
+	}
+	for path, content := range files {
+		path = filepath.Join(base, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	aggregateChecksum, err := p.generateChecksums()
+	require.NoError(t, err)
+	p.cfg.Pkg.Build.AggregateChecksum = aggregateChecksum
+
+	return p
+}
+
+func TestValidateChecksums(t *testing.T) {
+	tests := []struct {
+		name               string
+		modify             func(t *testing.T, base string)
+		unpulledComponents map[string]bool
+		wantErr            string
+	}{
+		{
+			name:   "intact package",
+			modify: func(t *testing.T, base string) {},
+		},
+		{
+			name: "tampered file",
+			modify: func(t *testing.T, base string) {
+				require.NoError(t, os.WriteFile(filepath.Join(base, "components/first/manifests/deployment.yaml"), []byte("tampered"), 0600))
+			},
+			wantErr: `component "first": manifests/deployment.yaml (expected`,
+		},
+		{
+			name: "missing file of a selected component",
+			modify: func(t *testing.T, base string) {
+				require.NoError(t, os.Remove(filepath.Join(base, "components/first/manifests/deployment.yaml")))
+			},
+			unpulledComponents: map[string]bool{"second": true},
+			wantErr:            `component "first": manifests/deployment.yaml (missing)`,
+		},
+		{
+			name: "missing file of an unpulled component",
+			modify: func(t *testing.T, base string) {
+				require.NoError(t, os.RemoveAll(filepath.Join(base, "components/second")))
+			},
+			unpulledComponents: map[string]bool{"second": true},
+		},
+		{
+			name: "missing file outside of the components",
+			modify: func(t *testing.T, base string) {
+				require.NoError(t, os.Remove(filepath.Join(base, "sboms/first.json")))
+			},
+			unpulledComponents: map[string]bool{"first": true, "second": true},
+			wantErr:            "sboms/first.json (missing)",
+		},
+		{
+			name: "unlisted file",
+			modify: func(t *testing.T, base string) {
+				require.NoError(t, os.WriteFile(filepath.Join(base, "components/first/extra"), []byte("extra"), 0600))
+			},
+			wantErr: "extra (not listed in checksums.txt)",
+		},
+		{
+			name: "tampered checksums",
+			modify: func(t *testing.T, base string) {
+				require.NoError(t, os.WriteFile(filepath.Join(base, config.ZarfChecksumsTxt), []byte(""), 0600))
+			},
+			wantErr: "does not match the aggregate checksum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newChecksumTestPackager(t)
+			p.unpulledComponents = tt.unpulledComponents
+			tt.modify(t, p.tmp.Base)
+
+			err := p.validateChecksums()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsUnpulled(t *testing.T) {
+	p := &Packager{
+		unpulledComponents: map[string]bool{"skipped": true},
+		unpulledImages:     map[string]bool{"index.docker.io/library/nginx:1.23": true},
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "components/skipped/files/0", want: true},
+		{path: "components/selected/files/0", want: false},
+		{path: "components/skipped-too/files/0", want: false},
+		{path: "images.tar/nginx:1.23", want: true},
+		{path: "images.tar/docker.io/library/nginx:1.23", want: true},
+		{path: "images.tar/nginx:1.24", want: false},
+		{path: "sboms/skipped.json", want: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, p.isUnpulled(tt.path), tt.path)
+	}
+}
+
+func TestReadChecksums(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.ZarfChecksumsTxt)
+
+	require.NoError(t, os.WriteFile(path, []byte("abc components/first/file with spaces\n\ndef sboms/first.json\n"), 0600))
+	checksums, err := readChecksums(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"components/first/file with spaces": "abc",
+		"sboms/first.json":                  "def",
+	}, checksums)
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid\n"), 0600))
+	_, err = readChecksums(path)
+	require.ErrorContains(t, err, "invalid line")
+}
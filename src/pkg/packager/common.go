@@ -29,6 +29,29 @@ type Packager struct {
 	cluster *cluster.Cluster
 	tmp     types.TempPaths
 	arch    string
+
+	// validComponents caches the components selected for deployment so the user is only prompted once
+	validComponents []types.ZarfComponent
+
+	// filterByDistro is only set while deploying, so that nothing else needs to reach a cluster to select components
+	filterByDistro bool
+	// clusterDistro caches the distro of the cluster that components are filtered by
+	clusterDistro string
+
+	// deployReport summarizes the last deployment for other tools
+	deployReport types.DeployReport
+
+	// previousRevisions keeps the revision each release had before this deployment changed it (0 if it was not
+	// installed), keyed by namespace/release, so a failed atomic deployment can be undone
+	previousRevisions map[string]int
+
+	// pendingRecorded tracks if the package being deployed has been recorded for the Zarf Agent image policy
+	pendingRecorded bool
+
+	// unpulledComponents and unpulledImages list the contents of an OCI package that were deliberately not pulled
+	// because the selected components do not need them
+	unpulledComponents map[string]bool
+	unpulledImages     map[string]bool
 }
 
 /*
@@ -153,12 +176,13 @@ func createPaths() (paths types.TempPaths, err error) {
 	paths = types.TempPaths{
 		Base: basePath,
 
-		InjectBinary: filepath.Join(basePath, "zarf-injector"),
-		SeedImage:    filepath.Join(basePath, "seed-image.tar"),
-		Images:       filepath.Join(basePath, "images.tar"),
-		Components:   filepath.Join(basePath, "components"),
-		Sboms:        filepath.Join(basePath, "sboms"),
-		ZarfYaml:     filepath.Join(basePath, config.ZarfYAML),
+		InjectBinary:   filepath.Join(basePath, "zarf-injector"),
+		SeedImage:      filepath.Join(basePath, "seed-image.tar"),
+		Images:         filepath.Join(basePath, "images.tar"),
+		ImageManifests: filepath.Join(basePath, "image-manifests.json"),
+		Components:     filepath.Join(basePath, "components"),
+		Sboms:          filepath.Join(basePath, "sboms"),
+		ZarfYaml:       filepath.Join(basePath, config.ZarfYAML),
 	}
 
 	return paths, err
@@ -173,27 +197,31 @@ func getRequestedComponentList(requestedComponents string) []string {
 }
 
 func (p *Packager) loadZarfPkg() error {
-	spinner := message.NewProgressSpinner("Loading Zarf Package %s", p.cfg.DeployOpts.PackagePath)
-	defer spinner.Stop()
-
+	// Fetch remote packages before starting the spinner so download progress can be shown
 	if err := p.handlePackagePath(); err != nil {
 		return fmt.Errorf("unable to handle the provided package path: %w", err)
 	}
 
+	spinner := message.NewProgressSpinner("Loading Zarf Package %s", p.cfg.DeployOpts.PackagePath)
+	defer spinner.Stop()
+
 	// Make sure the user gave us a package we can work with
 	if utils.InvalidPath(p.cfg.DeployOpts.PackagePath) {
 		return fmt.Errorf("unable to find the package at %s", p.cfg.DeployOpts.PackagePath)
 	}
 
-	// If packagePath has partial in the name, we need to combine the partials into a single package
-	if err := p.handleIfPartialPkg(); err != nil {
-		return fmt.Errorf("unable to process partial package: %w", err)
-	}
+	// Packages pulled from an OCI registry are already extracted into the temp directory
+	if p.cfg.DeployOpts.PackagePath != p.tmp.Base {
+		// If packagePath has partial in the name, we need to combine the partials into a single package
+		if err := p.handleIfPartialPkg(); err != nil {
+			return fmt.Errorf("unable to process partial package: %w", err)
+		}
 
-	// Extract the archive
-	spinner.Updatef("Extracting the package, this may take a few moments")
-	if err := archiver.Unarchive(p.cfg.DeployOpts.PackagePath, p.tmp.Base); err != nil {
-		return fmt.Errorf("unable to extract the package: %w", err)
+		// Extract the archive
+		spinner.Updatef("Extracting the package, this may take a few moments")
+		if err := archiver.Unarchive(p.cfg.DeployOpts.PackagePath, p.tmp.Base); err != nil {
+			return fmt.Errorf("unable to extract the package: %w", err)
+		}
 	}
 
 	// Load the config from the extracted archive zarf.yaml
@@ -203,6 +231,21 @@ func (p *Packager) loadZarfPkg() error {
 		return fmt.Errorf("unable to read the zarf.yaml in %s: %w", p.tmp.Base, err)
 	}
 
+	// Verify the package signature and contents before anything else is read from the package
+	spinner.Updatef("Verifying the package signature")
+	if err := p.verifyPackageSignature(); err != nil {
+		return err
+	}
+
+	spinner.Updatef("Validating the package checksums")
+	if p.cfg.Pkg.Build.AggregateChecksum == "" {
+		message.Warn("The package was created without checksums, skipping validation of the package contents")
+	} else {
+		if err := p.validateChecksums(); err != nil {
+			return err
+		}
+	}
+
 	// If SBOM files exist, temporarily place them in the deploy directory
 	if err := sbom.OutputSBOMFiles(p.tmp, config.ZarfSBOMDir, ""); err != nil {
 		// Don't stop the deployment, let the user decide if they want to continue the deployment
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
 	"github.com/defenseunicorns/zarf/src/pkg/k8s"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
@@ -23,6 +25,11 @@ const horizontalRule = "──────────────────
 func (p *Packager) getValidComponents() []types.ZarfComponent {
 	message.Debugf("packager.getValidComponents()")
 
+	// The selection may already have been made to pull only the needed parts of an OCI package
+	if p.validComponents != nil {
+		return p.validComponents
+	}
+
 	var validComponentsList []types.ZarfComponent
 	var orderedKeys []string
 	var choiceComponents []string
@@ -39,6 +46,11 @@ func (p *Packager) getValidComponents() []types.ZarfComponent {
 
 	// Break up components into choice groups
 	for _, component := range p.cfg.Pkg.Components {
+		// Skip the components that do not apply to the distro of the cluster
+		if !p.isCompatibleDistro(component) {
+			continue
+		}
+
 		key := component.Group
 		// If not a choice group, then use the component name as the key
 		if key == "" {
@@ -99,6 +111,13 @@ func (p *Packager) getValidComponents() []types.ZarfComponent {
 		message.Fatalf(err, "Invalid component argument, %s", err)
 	}
 
+	// Include the components the selected components depend on and order them to be deployed first
+	validComponentsList, err := p.resolveDependencies(validComponentsList)
+	if err != nil {
+		message.Fatalf(err, "Invalid component dependencies, %s", err)
+	}
+
+	p.validComponents = validComponentsList
 	return validComponentsList
 }
 
@@ -125,6 +144,121 @@ func (p *Packager) isCompatibleComponent(component types.ZarfComponent, filterBy
 	return validArch && validOS
 }
 
+// resolveDependencies adds the dependencies of the selected components that were not selected and returns the
+// components in deployment order, with every component after the components it depends on.
+func (p *Packager) resolveDependencies(selectedComponents []types.ZarfComponent) ([]types.ZarfComponent, error) {
+	message.Debugf("packager.resolveDependencies(%#v)", selectedComponents)
+
+	availableComponents := make(map[string]types.ZarfComponent)
+	for _, component := range p.cfg.Pkg.Components {
+		if p.isCompatibleDistro(component) {
+			availableComponents[component.Name] = component
+		}
+	}
+
+	selected := make(map[string]bool)
+	for _, component := range selectedComponents {
+		selected[component.Name] = true
+	}
+
+	var orderedComponents []types.ZarfComponent
+	added := make(map[string]bool)
+
+	var add func(component types.ZarfComponent) error
+	add = func(component types.ZarfComponent) error {
+		if added[component.Name] {
+			return nil
+		}
+		added[component.Name] = true
+
+		for _, dependency := range component.DependsOn {
+			dependencyComponent, ok := availableComponents[dependency]
+			if !ok {
+				return fmt.Errorf("component %s depends on %s which is not available for this cluster", component.Name, dependency)
+			}
+
+			if !selected[dependency] && !added[dependency] {
+				message.Notef("Including the %s component that the %s component depends on", dependency, component.Name)
+			}
+
+			if err := add(dependencyComponent); err != nil {
+				return err
+			}
+		}
+
+		orderedComponents = append(orderedComponents, component)
+		return nil
+	}
+
+	for _, component := range selectedComponents {
+		if err := add(component); err != nil {
+			return nil, err
+		}
+	}
+
+	return orderedComponents, nil
+}
+
+// isCompatibleDistro returns true if the component applies to the distro of the cluster, the distro is only resolved
+// (and the cluster only reached) during deploy.
+func (p *Packager) isCompatibleDistro(component types.ZarfComponent) bool {
+	if len(component.Only.Cluster.Distros) == 0 || !p.filterByDistro {
+		return true
+	}
+
+	if !k8s.MatchesDistro(component.Only.Cluster.Distros, p.clusterDistro) {
+		message.Debugf("Skipping component %s, %s is not compatible with %s", component.Name, strings.Join(component.Only.Cluster.Distros, ","), p.clusterDistro)
+		return false
+	}
+
+	return true
+}
+
+// resolveClusterDistro determines the distro of the cluster before components are selected for deployment, the
+// components limited to specific distros cannot be selected without it.
+func (p *Packager) resolveClusterDistro() error {
+	if !p.filterByDistro || p.clusterDistro != "" || !hasDistroLimitedComponents(p.cfg.Pkg.Components) {
+		return nil
+	}
+
+	distro, err := p.detectClusterDistro(30 * time.Second)
+	if err != nil {
+		return fmt.Errorf("unable to determine the cluster distro for the components limited to specific distros: %w", err)
+	}
+	p.clusterDistro = distro
+
+	return nil
+}
+
+// detectClusterDistro returns the distro recorded in the Zarf state or detects it if the cluster is not initialized.
+func (p *Packager) detectClusterDistro(timeout time.Duration) (string, error) {
+	if p.cluster == nil {
+		c, err := cluster.NewClusterWithWait(timeout)
+		if err != nil {
+			return k8s.DistroIsUnknown, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+		}
+		p.cluster = c
+	}
+
+	// YOLO packages do not record the actual distro in the state
+	if state, err := p.cluster.LoadZarfState(); err == nil && state.Distro != "" && state.Distro != "YOLO" {
+		return state.Distro, nil
+	}
+
+	return p.cluster.Kube.DetectDistro()
+}
+
+// hasDistroLimitedComponents returns true if any of the components is limited to specific distros.
+func hasDistroLimitedComponents(components []types.ZarfComponent) bool {
+	for _, component := range components {
+		if len(component.Only.Cluster.Distros) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Match on the first requested component that is not in the list of valid components and return the component name.
 func (p *Packager) validateRequests(validComponentsList []types.ZarfComponent, requestedComponentNames, choiceComponents []string) error {
 	message.Debugf("packager.validateRequests(%#v, %#v, %#v)", validComponentsList, requestedComponentNames, choiceComponents)
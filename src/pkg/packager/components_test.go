@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCompatibleDistro(t *testing.T) {
+	eksOnly := types.ZarfComponent{Name: "eks-only", Only: types.ZarfComponentOnlyTarget{Cluster: types.ZarfComponentOnlyCluster{Distros: []string{"eks*"}}}}
+	anyDistro := types.ZarfComponent{Name: "any-distro"}
+
+	// Outside of deploy the distro is never resolved, so this would hang trying to reach a cluster if it were
+	p := &Packager{cfg: &types.PackagerConfig{}}
+	require.True(t, p.isCompatibleDistro(eksOnly))
+	require.True(t, p.isCompatibleDistro(anyDistro))
+	require.Empty(t, p.clusterDistro)
+	require.Nil(t, p.cluster)
+
+	p = &Packager{cfg: &types.PackagerConfig{}, filterByDistro: true, clusterDistro: "kind"}
+	require.False(t, p.isCompatibleDistro(eksOnly))
+	require.True(t, p.isCompatibleDistro(anyDistro))
+
+	p.clusterDistro = "eksanywhere"
+	require.True(t, p.isCompatibleDistro(eksOnly))
+}
+
+func TestResolveClusterDistro(t *testing.T) {
+	eksOnly := types.ZarfComponent{Name: "eks-only", Only: types.ZarfComponentOnlyTarget{Cluster: types.ZarfComponentOnlyCluster{Distros: []string{"eks*"}}}}
+	anyDistro := types.ZarfComponent{Name: "any-distro"}
+
+	// None of these need the distro, so none of them may reach for a cluster
+	p := &Packager{cfg: &types.PackagerConfig{Pkg: types.ZarfPackage{Components: []types.ZarfComponent{eksOnly}}}}
+	require.NoError(t, p.resolveClusterDistro())
+
+	p = &Packager{cfg: &types.PackagerConfig{Pkg: types.ZarfPackage{Components: []types.ZarfComponent{anyDistro}}}, filterByDistro: true}
+	require.NoError(t, p.resolveClusterDistro())
+	require.Empty(t, p.clusterDistro)
+
+	p = &Packager{cfg: &types.PackagerConfig{Pkg: types.ZarfPackage{Components: []types.ZarfComponent{eksOnly}}}, filterByDistro: true, clusterDistro: "eks"}
+	require.NoError(t, p.resolveClusterDistro())
+	require.Nil(t, p.cluster)
+}
+
+func TestResolveDependencies(t *testing.T) {
+	components := []types.ZarfComponent{
+		{Name: "storage"},
+		{Name: "database", DependsOn: []string{"storage"}},
+		{Name: "cache", DependsOn: []string{"storage"}},
+		{Name: "app", DependsOn: []string{"database", "cache"}},
+		{Name: "eks-storage", Only: types.ZarfComponentOnlyTarget{Cluster: types.ZarfComponentOnlyCluster{Distros: []string{"eks*"}}}},
+		{Name: "eks-app", DependsOn: []string{"eks-storage"}},
+	}
+	byName := make(map[string]types.ZarfComponent)
+	for _, component := range components {
+		byName[component.Name] = component
+	}
+
+	tests := []struct {
+		name     string
+		selected []string
+		want     []string
+		wantErr  string
+	}{
+		{
+			name:     "no dependencies",
+			selected: []string{"storage"},
+			want:     []string{"storage"},
+		},
+		{
+			name:     "unselected dependencies are included first",
+			selected: []string{"app"},
+			want:     []string{"storage", "database", "cache", "app"},
+		},
+		{
+			name:     "selected dependencies are moved before their dependents",
+			selected: []string{"app", "cache", "storage"},
+			want:     []string{"storage", "database", "cache", "app"},
+		},
+		{
+			name:     "independent components keep their order",
+			selected: []string{"cache", "eks-storage"},
+			want:     []string{"storage", "cache", "eks-storage"},
+		},
+		{
+			name:     "dependency not available for the cluster distro",
+			selected: []string{"eks-app"},
+			wantErr:  "component eks-app depends on eks-storage which is not available for this cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		p := &Packager{cfg: &types.PackagerConfig{Pkg: types.ZarfPackage{Components: components}}, filterByDistro: true, clusterDistro: "kind"}
+
+		var selected []types.ZarfComponent
+		for _, name := range tt.selected {
+			selected = append(selected, byName[name])
+		}
+
+		resolved, err := p.resolveDependencies(selected)
+		if tt.wantErr != "" {
+			require.EqualError(t, err, tt.wantErr, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+
+		var names []string
+		for _, component := range resolved {
+			names = append(names, component.Name)
+		}
+		require.Equal(t, tt.want, names, tt.name)
+	}
+}
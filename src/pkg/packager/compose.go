@@ -172,6 +172,9 @@ func (p *Packager) mergeComponentOverrides(target *types.ZarfComponent, override
 	target.Required = override.Required
 	target.Group = override.Group
 
+	// Dependencies refer to components of the importing package
+	target.DependsOn = override.DependsOn
+
 	// Override description if it was provided.
 	if override.Description != "" {
 		target.Description = override.Description
@@ -189,6 +192,7 @@ func (p *Packager) mergeComponentOverrides(target *types.ZarfComponent, override
 	target.Images = append(target.Images, override.Images...)
 	target.Manifests = append(target.Manifests, override.Manifests...)
 	target.Repos = append(target.Repos, override.Repos...)
+	target.Wait = append(target.Wait, override.Wait...)
 
 	// Merge scripts.
 	target.Scripts.Before = append(target.Scripts.Before, override.Scripts.Before...)
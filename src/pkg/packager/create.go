@@ -61,6 +61,13 @@ func (p *Packager) Create(baseDir string) error {
 		return fmt.Errorf("unable to fill variables in template: %s", err.Error())
 	}
 
+	// Omit the images and repos that are already in the previous package version
+	if p.cfg.CreateOpts.DifferentialPackagePath != "" {
+		if err := p.removeDifferentialCopies(); err != nil {
+			return fmt.Errorf("unable to create a differential package: %w", err)
+		}
+	}
+
 	// Save the transformed config
 	if err := p.writeYaml(); err != nil {
 		return fmt.Errorf("unable to write zarf.yaml: %w", err)
@@ -78,7 +85,7 @@ func (p *Packager) Create(baseDir string) error {
 	if p.cfg.IsInitConfig {
 		// Load seed images into their own happy little tarball for ease of import on init
 		seedImage := fmt.Sprintf("%s:%s", config.ZarfSeedImage, config.ZarfSeedTag)
-		pulledImages, err := p.pullImages([]string{seedImage}, p.tmp.SeedImage)
+		pulledImages, err := p.pullImages([]string{seedImage}, p.tmp.SeedImage, "")
 		if err != nil {
 			return fmt.Errorf("unable to pull the seed image after 3 attempts: %w", err)
 		}
@@ -116,7 +123,7 @@ func (p *Packager) Create(baseDir string) error {
 		uniqueList := utils.Unique(combinedImageList)
 
 		var err error
-		if pulledImages, err = p.pullImages(uniqueList, p.tmp.Images); err != nil {
+		if pulledImages, err = p.pullImages(uniqueList, p.tmp.Images, p.tmp.ImageManifests); err != nil {
 			return fmt.Errorf("unable to pull images after 3 attempts: %w", err)
 		}
 	}
@@ -133,6 +140,18 @@ func (p *Packager) Create(baseDir string) error {
 		_ = os.Chdir(originalDir)
 	}
 
+	// Record the checksum of every file so the package contents can be verified when it is extracted
+	if err := p.addChecksums(); err != nil {
+		return fmt.Errorf("unable to add checksums to the package: %w", err)
+	}
+
+	// Sign the package if a signing key was provided
+	if p.cfg.CreateOpts.SigningKeyPath != "" {
+		if err := p.signPackage(); err != nil {
+			return fmt.Errorf("unable to sign the package: %w", err)
+		}
+	}
+
 	// Use the output path if the user specified it.
 	packageName := filepath.Join(p.cfg.CreateOpts.OutputDirectory, p.GetPackageName())
 
@@ -202,15 +221,16 @@ func (p *Packager) Create(baseDir string) error {
 	return nil
 }
 
-func (p *Packager) pullImages(imgList []string, path string) (map[name.Tag]v1.Image, error) {
+func (p *Packager) pullImages(imgList []string, path string, manifestsPath string) (map[name.Tag]v1.Image, error) {
 	var pulledImages map[name.Tag]v1.Image
 	var err error
 
 	return pulledImages, utils.Retry(func() error {
 		imgConfig := images.ImgConfig{
-			TarballPath: path,
-			ImgList:     imgList,
-			Insecure:    p.cfg.CreateOpts.Insecure,
+			TarballPath:   path,
+			ManifestsPath: manifestsPath,
+			ImgList:       imgList,
+			Insecure:      p.cfg.CreateOpts.Insecure,
 		}
 
 		pulledImages, err = imgConfig.PullAll()
@@ -5,12 +5,12 @@
 package packager
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/defenseunicorns/zarf/src/config"
@@ -18,26 +18,64 @@ import (
 	"github.com/defenseunicorns/zarf/src/internal/packager/git"
 	"github.com/defenseunicorns/zarf/src/internal/packager/helm"
 	"github.com/defenseunicorns/zarf/src/internal/packager/images"
+	"github.com/defenseunicorns/zarf/src/internal/packager/oci"
 	"github.com/defenseunicorns/zarf/src/internal/packager/template"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
 	"github.com/otiai10/copy"
 	"github.com/pterm/pterm"
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 )
 
 var valueTemplate template.Values
 var connectStrings = make(types.ConnectStrings)
 
+const (
+	dataInjectionSucceeded = "Succeeded"
+	dataInjectionFailed    = "Failed"
+)
+
 // Deploy attempts to deploy the given PackageConfig.
-func (p *Packager) Deploy() error {
+func (p *Packager) Deploy() (err error) {
 	message.Debug("packager.Deploy()")
 
+	p.deployReport = types.DeployReport{Components: []types.DeployReportComponent{}}
+	p.filterByDistro = true
+	start := time.Now()
+	defer func() {
+		p.deployReport.DurationSeconds = time.Since(start).Seconds()
+		p.deployReport.Success = err == nil
+		if err != nil {
+			p.deployReport.Error = err.Error()
+		}
+	}()
+
+	// The pending record is replaced by the package secret (if any) once the deployment has finished
+	defer func() {
+		if p.pendingRecorded {
+			if err := p.cluster.ClearPendingPackageDeployment(p.cfg.Pkg.Metadata.Name); err != nil {
+				message.Debugf("Unable to clear the pending package deployment: %s", err.Error())
+			}
+			p.pendingRecorded = false
+		}
+	}()
+
+	// Packages in an OCI registry or layout only need the layers of the components being deployed
+	if oci.IsOCIURL(p.cfg.DeployOpts.PackagePath) || oci.IsLayout(p.cfg.DeployOpts.PackagePath) {
+		if err := p.handleOciPackage(true); err != nil {
+			return fmt.Errorf("unable to pull the Zarf Package: %w", err)
+		}
+	}
+
 	if err := p.loadZarfPkg(); err != nil {
 		return fmt.Errorf("unable to load the Zarf Package: %w", err)
 	}
 
+	p.deployReport.Name = p.cfg.Pkg.Metadata.Name
+	p.deployReport.Version = p.cfg.Pkg.Metadata.Version
+
 	// Now that we have read the zarf.yaml, check the package kind
 	if p.cfg.Pkg.Kind == "ZarfInitConfig" {
 		p.cfg.IsInitConfig = true
@@ -48,6 +86,23 @@ func (p *Packager) Deploy() error {
 		utils.RunPreflightChecks()
 	}
 
+	// Components limited to specific distros are only selected once the distro of the cluster is known
+	if err := p.resolveClusterDistro(); err != nil {
+		return err
+	}
+
+	// Differential packages only work on top of the package version they were built against
+	if p.cfg.Pkg.Build.Differential {
+		if err := p.checkDifferentialBase(); err != nil {
+			return err
+		}
+	}
+
+	// The packages this package requires have to be deployed before any of its components run
+	if err := p.checkPackageRequirements(); err != nil {
+		return fmt.Errorf("unable to deploy the package: %w", err)
+	}
+
 	// Confirm the overall package deployment
 	if !p.confirmAction("Deploy", p.cfg.SBOMViewFiles) {
 		return fmt.Errorf("deployment cancelled")
@@ -61,9 +116,26 @@ func (p *Packager) Deploy() error {
 	// Get a list of all the components we are deploying and actually deploy them
 	deployedComponents, err := p.deployComponents()
 	if err != nil {
-		return fmt.Errorf("unable to deploy all components in this Zarf Package: %w", err)
+		deployErr := fmt.Errorf("unable to deploy all components in this Zarf Package: %w", err)
+
+		if p.cfg.DeployOpts.Atomic {
+			if err := p.undoDeployedComponents(deployedComponents); err != nil {
+				return fmt.Errorf("%w (unable to undo the deployment: %s)", deployErr, err.Error())
+			}
+			return deployErr
+		}
+
+		// Record what was deployed so it can be cleaned up with `zarf package remove`
+		if p.cluster != nil {
+			if err := p.cluster.RecordFailedPackageDeployment(p.cfg.Pkg, deployedComponents); err != nil {
+				message.Warnf("Unable to record the partially deployed package: %s", err.Error())
+			}
+		}
+		return deployErr
 	}
 
+	p.deployReport.ConnectStrings = connectStrings
+
 	// Notify all the things about the successful deployment
 	message.SuccessF("Zarf deployment complete")
 	p.printTablesForDeployment(deployedComponents)
@@ -71,7 +143,9 @@ func (p *Packager) Deploy() error {
 	// Save deployed package information to k8s
 	// Note: Not all packages need k8s; check if k8s is being used before saving the secret
 	if p.cluster != nil {
-		p.cluster.RecordPackageDeployment(p.cfg.Pkg, deployedComponents)
+		if err := p.cluster.RecordPackageDeployment(p.cfg.Pkg, deployedComponents); err != nil {
+			return fmt.Errorf("unable to record the package deployment: %w", err)
+		}
 	}
 
 	return nil
@@ -89,31 +163,139 @@ func (p *Packager) deployComponents() (deployedComponents []types.DeployedCompon
 	}
 
 	for _, component := range componentsToDeploy {
-		var charts []types.InstalledChart
+		var deployedComponent types.DeployedComponent
 
-		deployedComponent := types.DeployedComponent{Name: component.Name}
+		p.deployReport.Components = append(p.deployReport.Components, types.DeployReportComponent{Name: component.Name})
+		start := time.Now()
 
+		// Deploy the component
 		if p.cfg.IsInitConfig {
-			charts, err = p.deployInitComponent(component)
+			deployedComponent, err = p.deployInitComponent(component)
 		} else {
-			charts, err = p.deployComponent(component, false /* keep img checksum */)
+			deployedComponent, err = p.deployComponent(component, false /* keep img checksum */)
 		}
 
+		reportedComponent := p.reportedComponent()
+		reportedComponent.DurationSeconds = time.Since(start).Seconds()
+		reportedComponent.Success = err == nil
+		reportedComponent.InstalledCharts = deployedComponent.InstalledCharts
+		reportedComponent.DataInjections = deployedComponent.DataInjections
 		if err != nil {
-			return deployedComponents, fmt.Errorf("unable to deploy component %s: %w", component.Name, err)
+			reportedComponent.Error = err.Error()
 		}
 
-		// Deploy the component
-		deployedComponent.InstalledCharts = charts
+		// Keep the failed component so its status (e.g. data injections) is reported and its charts can be removed
+		if err != nil {
+			deployedComponent.Status = types.ComponentStatusFailed
+		}
 		deployedComponents = append(deployedComponents, deployedComponent)
 		config.SetDeployingComponents(deployedComponents)
+
+		if err != nil {
+			return deployedComponents, fmt.Errorf("unable to deploy component %s: %w", component.Name, err)
+		}
 	}
 
 	config.ClearDeployingComponents()
 	return deployedComponents, nil
 }
 
-func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []types.InstalledChart, err error) {
+// DeployReport returns the summary of the last deployment, including a failed one.
+func (p *Packager) DeployReport() types.DeployReport {
+	return p.deployReport
+}
+
+// reportedComponent returns the report of the component being deployed.
+func (p *Packager) reportedComponent() *types.DeployReportComponent {
+	if len(p.deployReport.Components) == 0 {
+		p.deployReport.Components = append(p.deployReport.Components, types.DeployReportComponent{})
+	}
+	return &p.deployReport.Components[len(p.deployReport.Components)-1]
+}
+
+// undoDeployedComponents removes the charts installed by this deployment, or rolls them back to the revision they
+// had before it, in the reverse order they were installed.
+func (p *Packager) undoDeployedComponents(deployedComponents []types.DeployedComponent) error {
+	message.Debugf("packager.undoDeployedComponents(%#v)", deployedComponents)
+
+	spinner := message.NewProgressSpinner("Undoing the deployment of %s", p.cfg.Pkg.Metadata.Name)
+	defer spinner.Stop()
+
+	undone := make(map[string]bool)
+	for i := len(deployedComponents) - 1; i >= 0; i-- {
+		component := deployedComponents[i]
+
+		for j := len(component.InstalledCharts) - 1; j >= 0; j-- {
+			installedChart := component.InstalledCharts[j]
+			key := releaseKey(installedChart)
+			if undone[key] {
+				continue
+			}
+			undone[key] = true
+
+			previousRevision, ok := p.previousRevisions[key]
+			if !ok {
+				message.Warnf("No previous revision was recorded for the helm chart (%s), leaving it as is", installedChart.ChartName)
+				continue
+			}
+
+			helmCfg := helm.Helm{}
+			revision, err := helmCfg.ReleaseRevision(installedChart.Namespace, installedChart.ChartName, spinner)
+			if err != nil {
+				return fmt.Errorf("unable to check the helm chart (%s) in the namespace (%s): %w", installedChart.ChartName, installedChart.Namespace, err)
+			}
+
+			switch {
+			case previousRevision == 0 && revision == 0:
+				// Never installed or already uninstalled after failing
+				continue
+
+			case previousRevision == 0:
+				spinner.Updatef("Uninstalling chart (%s) from the (%s) component", installedChart.ChartName, component.Name)
+				if err := helmCfg.RemoveChart(installedChart.Namespace, installedChart.ChartName, spinner); err != nil {
+					return fmt.Errorf("unable to remove the helm chart (%s) from the namespace (%s): %w", installedChart.ChartName, installedChart.Namespace, err)
+				}
+
+			case revision == 0:
+				return fmt.Errorf("the helm chart (%s) in the namespace (%s) was uninstalled and cannot be rolled back to revision %d", installedChart.ChartName, installedChart.Namespace, previousRevision)
+
+			default:
+				spinner.Updatef("Rolling back chart (%s) from the (%s) component to revision %d", installedChart.ChartName, component.Name, previousRevision)
+				if err := helmCfg.RollbackChart(installedChart.Namespace, installedChart.ChartName, previousRevision, spinner); err != nil {
+					return fmt.Errorf("unable to roll back the helm chart (%s) in the namespace (%s): %w", installedChart.ChartName, installedChart.Namespace, err)
+				}
+			}
+		}
+	}
+
+	spinner.Successf("Undid the deployment of %s", p.cfg.Pkg.Metadata.Name)
+	return nil
+}
+
+// keepPreviousRevision records the revision a release had before this deployment first changed it.
+func (p *Packager) keepPreviousRevision(installedChart types.InstalledChart, previousRevision int) {
+	if installedChart.ChartName == "" {
+		return
+	}
+
+	if p.previousRevisions == nil {
+		p.previousRevisions = make(map[string]int)
+	}
+
+	key := releaseKey(installedChart)
+	if _, ok := p.previousRevisions[key]; !ok {
+		p.previousRevisions[key] = previousRevision
+	}
+}
+
+// releaseKey identifies a helm release by its namespace and name.
+func releaseKey(installedChart types.InstalledChart) string {
+	return installedChart.Namespace + "/" + installedChart.ChartName
+}
+
+func (p *Packager) deployInitComponent(component types.ZarfComponent) (deployedComponent types.DeployedComponent, err error) {
+	deployedComponent = types.DeployedComponent{Name: component.Name}
+
 	hasExternalRegistry := p.cfg.InitOpts.RegistryInfo.Address != ""
 	isSeedRegistry := component.Name == "zarf-seed-registry"
 	isRegistry := component.Name == "zarf-registry"
@@ -124,14 +306,14 @@ func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []
 	if isSeedRegistry {
 		p.cluster, err = cluster.NewClusterWithWait(5 * time.Minute)
 		if err != nil {
-			return charts, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+			return deployedComponent, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
 		}
 		p.cluster.InitZarfState(p.tmp, p.cfg.InitOpts)
 	}
 
 	if hasExternalRegistry && (isSeedRegistry || isInjector || isRegistry) {
 		message.Notef("Not deploying the component (%s) since external registry information was provided during `zarf init`", component.Name)
-		return charts, nil
+		return deployedComponent, nil
 	}
 
 	// Before deploying the seed registry, start the injector
@@ -139,16 +321,16 @@ func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []
 		p.cluster.RunInjectionMadness(p.tmp)
 	}
 
-	charts, err = p.deployComponent(component, isAgent /* skip img checksum if isAgent */)
+	deployedComponent, err = p.deployComponent(component, isAgent /* skip img checksum if isAgent */)
 	if err != nil {
-		return charts, fmt.Errorf("unable to deploy component %s: %w", component.Name, err)
+		return deployedComponent, fmt.Errorf("unable to deploy component %s: %w", component.Name, err)
 	}
 
 	// Do cleanup for when we inject the seed registry during initialization
 	if isSeedRegistry {
 		err := p.cluster.PostSeedRegistry(p.tmp)
 		if err != nil {
-			return charts, fmt.Errorf("unable to seed the Zarf Registry: %w", err)
+			return deployedComponent, fmt.Errorf("unable to seed the Zarf Registry: %w", err)
 		}
 
 		seedImage := fmt.Sprintf("%s:%s", config.ZarfSeedImage, config.ZarfSeedTag)
@@ -161,21 +343,23 @@ func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []
 
 		// Push the seed images into to Zarf registry
 		if err = imgConfig.PushToZarfRegistry(); err != nil {
-			return charts, fmt.Errorf("unable to push the seed images to the Zarf Registry: %w", err)
+			return deployedComponent, fmt.Errorf("unable to push the seed images to the Zarf Registry: %w", err)
 		}
 	}
 
-	return charts, nil
+	return deployedComponent, nil
 }
 
 // Deploy a Zarf Component.
-func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum bool) (charts []types.InstalledChart, err error) {
+func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum bool) (deployedComponent types.DeployedComponent, err error) {
 	message.Debugf("packager.deployComponent(%#v, %#v", p.tmp, component)
 
+	deployedComponent = types.DeployedComponent{Name: component.Name}
+
 	// Toggles for general deploy operations
 	componentPath, err := p.createComponentPaths(component)
 	if err != nil {
-		return charts, fmt.Errorf("unable to create the component paths: %w", err)
+		return deployedComponent, fmt.Errorf("unable to create the component paths: %w", err)
 	}
 
 	// All components now require a name
@@ -189,11 +373,11 @@ func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum
 
 	// Run the 'before' scripts and move files before we do anything else
 	if err = p.runComponentScripts(component.Scripts.Before, component.Scripts); err != nil {
-		return charts, fmt.Errorf("unable to run the 'before' scripts: %w", err)
+		return deployedComponent, fmt.Errorf("unable to run the 'before' scripts: %w", err)
 	}
 
 	if err := p.processComponentFiles(component, componentPath.Files); err != nil {
-		return charts, fmt.Errorf("unable to process the component files: %w", err)
+		return deployedComponent, fmt.Errorf("unable to process the component files: %w", err)
 	}
 
 	if !valueTemplate.Ready() && (hasImages || hasCharts || hasManifests || hasRepos) {
@@ -202,44 +386,92 @@ func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum
 		if p.cluster == nil {
 			p.cluster, err = cluster.NewClusterWithWait(30 * time.Second)
 			if err != nil {
-				return charts, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+				return deployedComponent, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
 			}
 		}
 
 		valueTemplate, err = p.getUpdatedValueTemplate(component)
 		if err != nil {
-			return charts, fmt.Errorf("unable to get the updated value template: %w", err)
+			return deployedComponent, fmt.Errorf("unable to get the updated value template: %w", err)
 		}
 	}
 
 	if hasImages {
 		if err := p.pushImagesToRegistry(component.Images, noImgChecksum); err != nil {
-			return charts, fmt.Errorf("unable to push images to the registry: %w", err)
+			return deployedComponent, fmt.Errorf("unable to push images to the registry: %w", err)
 		}
+		p.reportedComponent().ImagesPushed = component.Images
 	}
 
 	if hasRepos {
 		if err = p.pushReposToRepository(componentPath.Repos, component.Repos); err != nil {
-			return charts, fmt.Errorf("unable to push the repos to the repository: %w", err)
+			return deployedComponent, fmt.Errorf("unable to push the repos to the repository: %w", err)
+		}
+		p.reportedComponent().ReposPushed = component.Repos
+	}
+
+	if hasCharts {
+		chartsPushed, err := p.pushChartsToRegistry(componentPath.Charts, component.Charts)
+		if err != nil {
+			return deployedComponent, fmt.Errorf("unable to push the charts to the registry: %w", err)
 		}
+		p.reportedComponent().ChartsPushed = chartsPushed
 	}
 
+	var waitForDataInjections func() ([]types.DeployedDataInjection, error)
 	if hasDataInjections {
-		waitGroup := sync.WaitGroup{}
-		defer waitGroup.Wait()
-		p.performDataInjections(&waitGroup, componentPath, component.DataInjections)
+		ctx, cancelDataInjections := context.WithCancel(context.Background())
+		defer cancelDataInjections()
+		wait := p.performDataInjections(ctx, componentPath, component.DataInjections)
+
+		// Stop the injections if the component fails before they finish, they must not keep exec'ing into pods while
+		// the failed deployment is cleaned up
+		waited := false
+		waitForDataInjections = func() ([]types.DeployedDataInjection, error) {
+			waited = true
+			return wait()
+		}
+		defer func() {
+			if !waited {
+				cancelDataInjections()
+				deployedComponent.DataInjections, _ = waitForDataInjections()
+			}
+		}()
 	}
 
 	if hasCharts || hasManifests {
-		if charts, err = p.installChartAndManifests(componentPath, component); err != nil {
-			return charts, fmt.Errorf("unable to install helm chart(s): %w", err)
+		// Let the Zarf Agent image policy know about the images of this package before its pods are created
+		if p.cluster != nil && !p.pendingRecorded {
+			if err := p.cluster.RecordPendingPackageDeployment(p.cfg.Pkg, p.getValidComponents()); err != nil {
+				return deployedComponent, fmt.Errorf("unable to record the pending package deployment: %w", err)
+			}
+			p.pendingRecorded = true
+		}
+
+		if deployedComponent.InstalledCharts, err = p.installChartAndManifests(componentPath, component); err != nil {
+			return deployedComponent, fmt.Errorf("unable to install helm chart(s): %w", err)
+		}
+	}
+
+	// The data injections usually target pods from the charts above, so only wait for them now
+	if hasDataInjections {
+		deployedComponent.DataInjections, err = waitForDataInjections()
+		if err != nil {
+			return deployedComponent, fmt.Errorf("unable to perform the data injections: %w", err)
+		}
+	}
+
+	// Wait for the resources of the component to be ready before anything that may rely on them
+	if len(component.Wait) > 0 {
+		if err := p.waitForComponentConditions(component.Wait); err != nil {
+			return deployedComponent, fmt.Errorf("unable to wait for the component conditions: %w", err)
 		}
 	}
 
 	// Run the 'after' scripts after all other attributes of the component has been deployed
 	p.runComponentScripts(component.Scripts.After, component.Scripts)
 
-	return charts, nil
+	return deployedComponent, nil
 }
 
 // Move files onto the host of the machine performing the deployment.
@@ -340,6 +572,14 @@ func (p *Packager) getUpdatedValueTemplate(component types.ZarfComponent) (value
 			"the pod or namespace label `zarf.dev/agent: ignore'.")
 	}
 
+	// Renew the Zarf Agent certificate before it expires and the agent stops admitting pods
+	if state.Distro != "YOLO" && cluster.IsAgentTLSExpiring(state) {
+		spinner.Updatef("Rotating the Zarf Agent certificate before it expires")
+		if state, err = p.cluster.RotateAgentTLS(state); err != nil {
+			return values, fmt.Errorf("unable to rotate the Zarf Agent certificate: %w", err)
+		}
+	}
+
 	p.cfg.State = state
 
 	// Continue loading state data if it is valid
@@ -366,10 +606,11 @@ func (p *Packager) pushImagesToRegistry(componentImages []string, noImgChecksum
 	}
 
 	imgConfig := images.ImgConfig{
-		TarballPath: p.tmp.Images,
-		ImgList:     componentImages,
-		NoChecksum:  noImgChecksum,
-		RegInfo:     p.cfg.State.RegistryInfo,
+		TarballPath:   p.tmp.Images,
+		ManifestsPath: p.tmp.ImageManifests,
+		ImgList:       componentImages,
+		NoChecksum:    noImgChecksum,
+		RegInfo:       p.cfg.State.RegistryInfo,
 	}
 
 	return utils.Retry(func() error {
@@ -416,15 +657,71 @@ func (p *Packager) pushReposToRepository(reposPath string, repos []string) error
 	return nil
 }
 
+// Push the charts that ask for it to the configured container registry as OCI artifacts.
+func (p *Packager) pushChartsToRegistry(chartsPath string, charts []types.ZarfChart) ([]string, error) {
+	var chartsPushed []string
+
+	for _, chart := range charts {
+		if !chart.PushToRegistry {
+			continue
+		}
+
+		helmCfg := helm.Helm{
+			Chart: chart,
+			Cfg:   p.cfg,
+		}
+
+		var ref string
+		tryPush := func() (err error) {
+			ref, err = helmCfg.PushChartToRegistry(chartsPath)
+			return err
+		}
+
+		// Try chart push up to 3 times
+		if err := utils.Retry(tryPush, 3, 5*time.Second); err != nil {
+			return chartsPushed, err
+		}
+
+		message.Infof("Pushed the helm chart %s:%s to %s", chart.Name, chart.Version, ref)
+		chartsPushed = append(chartsPushed, ref)
+	}
+
+	return chartsPushed, nil
+}
+
 // Async move data into a container running in a pod on the k8s cluster.
-func (p *Packager) performDataInjections(waitGroup *sync.WaitGroup, componentPath types.ComponentPaths, dataInjections []types.ZarfDataInjection) {
+// The returned function waits for all of the injections to finish and returns their status and the first error, the
+// remaining injections are canceled as soon as one fails or the context is canceled.
+func (p *Packager) performDataInjections(ctx context.Context, componentPath types.ComponentPaths, dataInjections []types.ZarfDataInjection) func() ([]types.DeployedDataInjection, error) {
 	if len(dataInjections) > 0 {
 		message.Info("Loading data injections")
 	}
 
-	for _, data := range dataInjections {
-		waitGroup.Add(1)
-		go p.cluster.HandleDataInjection(waitGroup, data, componentPath)
+	results := make([]types.DeployedDataInjection, len(dataInjections))
+	group, ctx := errgroup.WithContext(ctx)
+
+	for idx, data := range dataInjections {
+		idx, data := idx, data
+		group.Go(func() error {
+			results[idx] = types.DeployedDataInjection{
+				Source: data.Source,
+				Target: fmt.Sprintf("%s/%s:%s", data.Target.Namespace, data.Target.Selector, data.Target.Path),
+				Status: dataInjectionSucceeded,
+			}
+
+			if err := p.cluster.HandleDataInjection(ctx, data, componentPath); err != nil {
+				results[idx].Status = dataInjectionFailed
+				results[idx].Error = err.Error()
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	return func() ([]types.DeployedDataInjection, error) {
+		err := group.Wait()
+		return results, err
 	}
 }
 
@@ -450,11 +747,16 @@ func (p *Packager) installChartAndManifests(componentPath types.ComponentPaths,
 			Cluster:   p.cluster,
 		}
 
-		addedConnectStrings, installedChartName, err := helmCfg.InstallOrUpgradeChart()
+		addedConnectStrings, installedChart, err := helmCfg.InstallOrUpgradeChart()
+		p.keepPreviousRevision(installedChart, helmCfg.PreviousRevision)
 		if err != nil {
+			// The release may be partially installed, so keep it to be undone or removed
+			if installedChart.ChartName != "" {
+				installedCharts = append(installedCharts, installedChart)
+			}
 			return installedCharts, err
 		}
-		installedCharts = append(installedCharts, types.InstalledChart{Namespace: chart.Namespace, ChartName: installedChartName})
+		installedCharts = append(installedCharts, installedChart)
 
 		// Iterate over any connectStrings and add to the main map
 		for name, description := range addedConnectStrings {
@@ -481,11 +783,16 @@ func (p *Packager) installChartAndManifests(componentPath types.ComponentPaths,
 			Cfg:       p.cfg,
 			Cluster:   p.cluster,
 		}
-		addedConnectStrings, installedChartName, err := helmCfg.GenerateChart(manifest)
+		addedConnectStrings, installedChart, err := helmCfg.GenerateChart(manifest)
+		p.keepPreviousRevision(installedChart, helmCfg.PreviousRevision)
 		if err != nil {
+			// The release may be partially installed, so keep it to be undone or removed
+			if installedChart.ChartName != "" {
+				installedCharts = append(installedCharts, installedChart)
+			}
 			return installedCharts, err
 		}
-		installedCharts = append(installedCharts, types.InstalledChart{Namespace: manifest.Namespace, ChartName: installedChartName})
+		installedCharts = append(installedCharts, installedChart)
 
 		// Iterate over any connectStrings and add to the main map
 		for name, description := range addedConnectStrings {
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/internal/packager/oci"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/mholt/archiver/v3"
+)
+
+// removeDifferentialCopies removes the images and git repos that are already in the package given to --differential
+// from the components being created, and marks the package as differential.
+func (p *Packager) removeDifferentialCopies() error {
+	message.Debugf("packager.removeDifferentialCopies(%s)", p.cfg.CreateOpts.DifferentialPackagePath)
+
+	basePkg, err := p.loadDifferentialBase()
+	if err != nil {
+		return err
+	}
+
+	if basePkg.Metadata.Name != p.cfg.Pkg.Metadata.Name {
+		return fmt.Errorf("the differential package %s must have the same name as the package being created, got %s", basePkg.Metadata.Name, p.cfg.Pkg.Metadata.Name)
+	}
+
+	if basePkg.Metadata.Version == "" || p.cfg.Pkg.Metadata.Version == "" {
+		return fmt.Errorf("both the package being created and the differential package must set metadata.version")
+	}
+
+	if basePkg.Metadata.Version == p.cfg.Pkg.Metadata.Version {
+		return fmt.Errorf("the package being created has the same version as the differential package (%s)", basePkg.Metadata.Version)
+	}
+
+	existingImages := map[string]bool{}
+	existingRepos := map[string]bool{}
+
+	// Images left out of a differential base are in the cluster as well since its own base has to be deployed
+	for _, image := range basePkg.Build.DifferentialMissing {
+		existingImages[image] = true
+	}
+	for _, component := range basePkg.Components {
+		for _, image := range component.Images {
+			existingImages[image] = true
+		}
+		for _, repo := range component.Repos {
+			existingRepos[repo] = true
+		}
+	}
+
+	var removedRepos int
+	missingImages := map[string]bool{}
+	for idx, component := range p.cfg.Pkg.Components {
+		var newImages, newRepos []string
+
+		for _, image := range component.Images {
+			// Images referenced by a mutable tag may have changed even if the ref didn't
+			if existingImages[image] && !isMutableImageRef(image) {
+				missingImages[image] = true
+				continue
+			}
+			newImages = append(newImages, image)
+		}
+
+		for _, repo := range component.Repos {
+			// Repos without a tag or commit are mirrored in full and may have changed
+			if existingRepos[repo] && strings.Contains(repo, "@") {
+				removedRepos++
+				continue
+			}
+			newRepos = append(newRepos, repo)
+		}
+
+		p.cfg.Pkg.Components[idx].Images = newImages
+		p.cfg.Pkg.Components[idx].Repos = newRepos
+	}
+
+	// The omitted images are still used by the package, so keep track of them for the Zarf Agent image policy
+	for image := range missingImages {
+		p.cfg.Pkg.Build.DifferentialMissing = append(p.cfg.Pkg.Build.DifferentialMissing, image)
+	}
+	sort.Strings(p.cfg.Pkg.Build.DifferentialMissing)
+
+	p.cfg.Pkg.Build.Differential = true
+	p.cfg.Pkg.Build.DifferentialPackageVersion = basePkg.Metadata.Version
+
+	message.Notef("Creating a differential package against %s version %s, omitting %d unchanged images and %d unchanged git repos",
+		basePkg.Metadata.Name, basePkg.Metadata.Version, len(missingImages), removedRepos)
+
+	return nil
+}
+
+// loadDifferentialBase reads the zarf.yaml of the package given to --differential from a package tarball, an OCI
+// registry or a local OCI image layout.
+func (p *Packager) loadDifferentialBase() (types.ZarfPackage, error) {
+	var basePkg types.ZarfPackage
+
+	source := p.cfg.CreateOpts.DifferentialPackagePath
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return basePkg, fmt.Errorf("unable to create a temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if oci.IsOCIURL(source) || oci.IsLayout(source) {
+		var pkg *oci.Package
+		if oci.IsLayout(source) {
+			pkg, err = oci.NewLayoutPackage(source)
+		} else {
+			var ref name.Reference
+			if ref, err = oci.ParseReference(source, p.cfg.CreateOpts.Insecure); err != nil {
+				return basePkg, err
+			}
+			pkg, err = oci.NewRemotePackage(ref)
+		}
+		if err != nil {
+			return basePkg, err
+		}
+
+		zarfYamlLayer, ok := pkg.Layer(config.ZarfYAML)
+		if !ok {
+			return basePkg, fmt.Errorf("unable to find %s in %s", config.ZarfYAML, pkg.Source)
+		}
+
+		if err := pkg.PullLayers([]v1.Descriptor{zarfYamlLayer}, tmpDir); err != nil {
+			return basePkg, fmt.Errorf("unable to pull %s from %s: %w", config.ZarfYAML, pkg.Source, err)
+		}
+	} else {
+		if utils.InvalidPath(source) {
+			return basePkg, fmt.Errorf("unable to find the differential package at %s", source)
+		}
+
+		if err := archiver.Extract(source, config.ZarfYAML, tmpDir); err != nil {
+			return basePkg, fmt.Errorf("unable to extract %s from %s: %w", config.ZarfYAML, source, err)
+		}
+	}
+
+	if err := utils.ReadYaml(filepath.Join(tmpDir, config.ZarfYAML), &basePkg); err != nil {
+		return basePkg, fmt.Errorf("unable to read the zarf.yaml of the differential package: %w", err)
+	}
+
+	return basePkg, nil
+}
+
+// checkDifferentialBase ensures the package a differential package was built against is deployed to the cluster.
+func (p *Packager) checkDifferentialBase() error {
+	message.Debugf("packager.checkDifferentialBase(%s)", p.cfg.Pkg.Build.DifferentialPackageVersion)
+
+	spinner := message.NewProgressSpinner("Checking the cluster for %s version %s", p.cfg.Pkg.Metadata.Name, p.cfg.Pkg.Build.DifferentialPackageVersion)
+	defer spinner.Stop()
+
+	var err error
+	if p.cluster == nil {
+		if p.cluster, err = cluster.NewClusterWithWait(30 * time.Second); err != nil {
+			return fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+		}
+	}
+
+	deployedPackages, err := p.cluster.GetDeployedZarfPackages()
+	if err != nil {
+		return fmt.Errorf("unable to get the packages deployed to the cluster: %w", err)
+	}
+
+	for _, deployedPackage := range deployedPackages {
+		if deployedPackage.Name != p.cfg.Pkg.Metadata.Name {
+			continue
+		}
+
+		deployedVersion := deployedPackage.Data.Metadata.Version
+		if deployedVersion != p.cfg.Pkg.Build.DifferentialPackageVersion {
+			return fmt.Errorf("this differential package requires version %s of %s to be deployed, found version %s",
+				p.cfg.Pkg.Build.DifferentialPackageVersion, p.cfg.Pkg.Metadata.Name, deployedVersion)
+		}
+
+		spinner.Successf("Found %s version %s in the cluster", p.cfg.Pkg.Metadata.Name, deployedVersion)
+		return nil
+	}
+
+	return fmt.Errorf("this differential package requires version %s of %s to be deployed, but the package is not deployed to the cluster",
+		p.cfg.Pkg.Build.DifferentialPackageVersion, p.cfg.Pkg.Metadata.Name)
+}
+
+// isMutableImageRef returns true if the image is referenced by a tag that is commonly overwritten.
+func isMutableImageRef(image string) bool {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return true
+	}
+
+	if tag, ok := ref.(name.Tag); ok {
+		return tag.TagStr() == "latest"
+	}
+
+	return false
+}
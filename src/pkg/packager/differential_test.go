@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsMutableImageRef(t *testing.T) {
+	tests := []struct {
+		image string
+		want  bool
+	}{
+		{image: "nginx", want: true},
+		{image: "nginx:latest", want: true},
+		{image: "ghcr.io/stefanprodan/podinfo:latest", want: true},
+		{image: "nginx:1.23.3", want: false},
+		{image: "registry:5000/app:v1", want: false},
+		{image: "nginx@sha256:aa0afebbb3cfa473099a62c4b32e9b3fb73ed23f2a75a65ce1d4b4f55a5c2ef2", want: false},
+		{image: "not a valid image", want: true},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, isMutableImageRef(tt.image), tt.image)
+	}
+}
+
+func TestRemoveDifferentialCopies(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	basePkg := types.ZarfPackage{
+		Kind:     "ZarfPackageConfig",
+		Metadata: types.ZarfMetadata{Name: "app", Version: "1.0.0"},
+		Build:    types.ZarfBuildData{DifferentialMissing: []string{"busybox:1.35"}},
+		Components: []types.ZarfComponent{
+			{
+				Name:   "app",
+				Images: []string{"nginx:1.23.3", "nginx:latest", "redis:7.0"},
+				Repos:  []string{"https://github.com/defenseunicorns/zarf.git@v0.23.0", "https://github.com/stefanprodan/podinfo.git"},
+			},
+		},
+	}
+	require.NoError(t, utils.WriteYaml(filepath.Join(tmpDir, config.ZarfYAML), basePkg, 0600))
+
+	basePath := filepath.Join(tmpDir, "zarf-package-app-amd64-1.0.0.tar.zst")
+	require.NoError(t, archiver.Archive([]string{filepath.Join(tmpDir, config.ZarfYAML)}, basePath))
+
+	p := &Packager{cfg: &types.PackagerConfig{
+		CreateOpts: types.ZarfCreateOptions{DifferentialPackagePath: basePath},
+		Pkg: types.ZarfPackage{
+			Kind:     "ZarfPackageConfig",
+			Metadata: types.ZarfMetadata{Name: "app", Version: "1.1.0"},
+			Components: []types.ZarfComponent{
+				{
+					Name:   "app",
+					Images: []string{"nginx:1.23.3", "nginx:latest", "redis:7.2", "busybox:1.35"},
+					Repos:  []string{"https://github.com/defenseunicorns/zarf.git@v0.23.0", "https://github.com/stefanprodan/podinfo.git"},
+				},
+			},
+		},
+	}}
+
+	require.NoError(t, p.removeDifferentialCopies())
+
+	require.Equal(t, []string{"nginx:latest", "redis:7.2"}, p.cfg.Pkg.Components[0].Images)
+	require.Equal(t, []string{"https://github.com/stefanprodan/podinfo.git"}, p.cfg.Pkg.Components[0].Repos)
+	require.Equal(t, []string{"busybox:1.35", "nginx:1.23.3"}, p.cfg.Pkg.Build.DifferentialMissing)
+	require.True(t, p.cfg.Pkg.Build.Differential)
+	require.Equal(t, "1.0.0", p.cfg.Pkg.Build.DifferentialPackageVersion)
+
+	// The same version cannot be used as its own base
+	p.cfg.Pkg.Metadata.Version = "1.0.0"
+	require.ErrorContains(t, p.removeDifferentialCopies(), "same version")
+}
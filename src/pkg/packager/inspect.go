@@ -6,23 +6,36 @@ package packager
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/defenseunicorns/zarf/src/internal/packager/sbom"
+	"github.com/defenseunicorns/zarf/src/pkg/k8s"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/pterm/pterm"
 )
 
-// Inspect list the contents of a package.
-func (p *Packager) Inspect(includeSBOM bool, outputSBOM string) error {
+// Inspect list the contents of a package, printing the zarf.yaml as JSON or YAML to stdout if an output format is set.
+func (p *Packager) Inspect(includeSBOM bool, outputSBOM string, outputFormat string) error {
 
 	if err := p.loadZarfPkg(); err != nil {
 		return fmt.Errorf("unable to load the package: %w", err)
 	}
 
-	pterm.Println()
-	pterm.Println()
+	if outputFormat != "" {
+		if err := utils.PrintStructured(outputFormat, p.cfg.Pkg); err != nil {
+			return err
+		}
+	} else {
+		pterm.Println()
+		pterm.Println()
+
+		utils.ColorPrintYAML(p.cfg.Pkg)
 
-	utils.ColorPrintYAML(p.cfg.Pkg)
+		// Show which components apply to the current cluster if any are limited to specific distros
+		p.printComponentDistros()
+	}
 
 	// Open a browser to view the SBOM if specified
 	if includeSBOM {
@@ -38,3 +51,46 @@ func (p *Packager) Inspect(includeSBOM bool, outputSBOM string) error {
 
 	return nil
 }
+
+// printComponentDistros prints a table of the distros each component is limited to and whether they apply to the
+// current cluster, which is only looked up briefly since inspect runs offline.
+func (p *Packager) printComponentDistros() {
+	if !hasDistroLimitedComponents(p.cfg.Pkg.Components) {
+		return
+	}
+
+	distro, err := p.detectClusterDistro(5 * time.Second)
+	if err != nil {
+		message.Debugf("Unable to determine the cluster distro: %s", err.Error())
+		distro = ""
+	}
+
+	appliesHeader := "Applies to cluster"
+	if distro != "" {
+		appliesHeader = fmt.Sprintf("Applies to %s", distro)
+	}
+
+	distroTable := pterm.TableData{
+		{"     Component", "Distros", appliesHeader},
+	}
+
+	for _, component := range p.cfg.Pkg.Components {
+		distros := "all"
+		if len(component.Only.Cluster.Distros) > 0 {
+			distros = strings.Join(component.Only.Cluster.Distros, ", ")
+		}
+
+		applies := "unknown"
+		if distro != "" {
+			applies = "no"
+			if k8s.MatchesDistro(component.Only.Cluster.Distros, distro) {
+				applies = "yes"
+			}
+		}
+
+		distroTable = append(distroTable, []string{"     " + component.Name, distros, applies})
+	}
+
+	pterm.Println()
+	_ = pterm.DefaultTable.WithHasHeader().WithData(distroTable).Render()
+}
@@ -13,8 +13,12 @@ import (
 	"strings"
 
 	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/packager/images"
+	"github.com/defenseunicorns/zarf/src/internal/packager/oci"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 )
 
 // handlePackagePath If provided package is a URL download it to a temp directory.
@@ -23,6 +27,11 @@ func (p *Packager) handlePackagePath() error {
 
 	opts := p.cfg.DeployOpts
 
+	// Handle case where deploying a package stored in a local OCI image layout
+	if oci.IsLayout(opts.PackagePath) {
+		return p.handleOciPackage(false)
+	}
+
 	// Check if the user gave us a remote package
 	providedURL, err := url.Parse(opts.PackagePath)
 	if err != nil || providedURL.Scheme == "" || providedURL.Host == "" {
@@ -30,13 +39,18 @@ func (p *Packager) handlePackagePath() error {
 		return nil
 	}
 
+	// Handle case where deploying remote package stored in an OCI registry
+	if oci.IsOCIURL(opts.PackagePath) {
+		return p.handleOciPackage(false)
+	}
+
 	// Handle case where deploying remote package validated via sget
 	if strings.HasPrefix(opts.PackagePath, "sget://") {
 		return p.handleSgetPackage()
 	}
 
 	if !opts.Insecure && opts.Shasum == "" {
-		return fmt.Errorf("remote package provided without a shasum, use --insecure-deploy to ignore")
+		return fmt.Errorf("remote package provided without a shasum, use --insecure to ignore")
 	}
 
 	// Check the extension on the package is what we expect
@@ -53,14 +67,24 @@ func (p *Packager) handlePackagePath() error {
 
 	localPath := p.tmp.Base + providedURL.Path
 	message.Debugf("Creating local package with the path: %s", localPath)
-	packageFile, _ := os.Create(localPath)
+	packageFile, err := os.Create(localPath)
+	if err != nil {
+		return fmt.Errorf("unable to create the local package file: %w", err)
+	}
+	defer packageFile.Close()
+
 	_, err = io.Copy(packageFile, resp.Body)
 	if err != nil {
 		return fmt.Errorf("unable to copy the contents of the provided URL into a local file: %w", err)
 	}
 
 	// Check the shasum if necessary
-	if !opts.Insecure {
+	if opts.Shasum != "" {
+		// Hash the file from the start, the copy above left it at the end
+		if _, err := packageFile.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("unable to read the downloaded remote package: %w", err)
+		}
+
 		hasher := sha256.New()
 		_, err = io.Copy(hasher, packageFile)
 		if err != nil {
@@ -74,7 +98,7 @@ func (p *Packager) handlePackagePath() error {
 		}
 	}
 
-	opts.PackagePath = localPath
+	p.cfg.DeployOpts.PackagePath = localPath
 
 	return nil
 }
@@ -111,3 +135,151 @@ func (p *Packager) handleSgetPackage() error {
 
 	return nil
 }
+
+// handleOciPackage pulls a package from an OCI registry or a local OCI image layout into the temp directory.
+// If onlySelected is set, only the layers needed to deploy the selected components are pulled.
+func (p *Packager) handleOciPackage(onlySelected bool) error {
+	message.Debugf("packager.handleOciPackage(%t)", onlySelected)
+
+	opts := p.cfg.DeployOpts
+
+	var (
+		pkg *oci.Package
+		err error
+	)
+
+	if oci.IsLayout(opts.PackagePath) {
+		pkg, err = oci.NewLayoutPackage(opts.PackagePath)
+	} else {
+		var ref name.Reference
+		if ref, err = oci.ParseReference(opts.PackagePath, opts.PlainHTTP); err != nil {
+			return err
+		}
+		pkg, err = oci.NewRemotePackage(ref)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The manifest digest covers every layer digest, so it is the equivalent of the package shasum
+	if opts.Shasum != "" && opts.Shasum != pkg.Digest.Hex {
+		return fmt.Errorf("digest of remote package does not match provided shasum, expected %s, got %s", opts.Shasum, pkg.Digest.Hex)
+	}
+
+	layers := pkg.Manifest.Layers
+
+	if onlySelected {
+		if layers, err = p.getSelectedOciLayers(pkg); err != nil {
+			return err
+		}
+	}
+
+	if err := pkg.PullLayers(layers, p.tmp.Base); err != nil {
+		return fmt.Errorf("unable to pull the package from %s: %w", pkg.Source, err)
+	}
+
+	// Images are pulled as individual tarballs, recombine them into the package images tarball
+	imagesDir := filepath.Join(p.tmp.Base, oci.ImagesDir)
+	imageTarballs, _ := filepath.Glob(filepath.Join(imagesDir, "*.tar"))
+	if len(imageTarballs) > 0 {
+		if err := oci.MergeImageTarballs(imageTarballs, p.tmp.Images); err != nil {
+			return fmt.Errorf("unable to combine the package images: %w", err)
+		}
+	}
+	_ = os.RemoveAll(imagesDir)
+
+	// The package is pulled already extracted, so point the package path at the temp directory
+	p.cfg.DeployOpts.PackagePath = p.tmp.Base
+
+	return nil
+}
+
+// getSelectedOciLayers pulls the zarf.yaml of an OCI package (and its signature and checksums) to verify it and resolve
+// the components to deploy, and returns the remaining layers needed to deploy only those components and their images.
+func (p *Packager) getSelectedOciLayers(pkg *oci.Package) ([]v1.Descriptor, error) {
+	zarfYamlLayer, ok := pkg.Layer(config.ZarfYAML)
+	if !ok {
+		return nil, fmt.Errorf("unable to find %s in %s", config.ZarfYAML, pkg.Source)
+	}
+
+	// The signature and checksums are optional, but have to be pulled with the zarf.yaml so it can be verified
+	metadataLayers := []v1.Descriptor{zarfYamlLayer}
+	for _, title := range []string{config.ZarfYAMLSignature, config.ZarfChecksumsTxt} {
+		if layer, ok := pkg.Layer(title); ok {
+			metadataLayers = append(metadataLayers, layer)
+		}
+	}
+
+	if err := pkg.PullLayers(metadataLayers, p.tmp.Base); err != nil {
+		return nil, fmt.Errorf("unable to pull %s from %s: %w", config.ZarfYAML, pkg.Source, err)
+	}
+
+	// Nothing in the zarf.yaml can be trusted to decide what to pull until its signature has been verified
+	if err := p.verifyPackageSignature(); err != nil {
+		return nil, err
+	}
+
+	if err := p.readYaml(p.tmp.ZarfYaml, true); err != nil {
+		return nil, fmt.Errorf("unable to read the zarf.yaml in %s: %w", p.tmp.Base, err)
+	}
+
+	if p.cfg.Pkg.Kind == "ZarfInitConfig" {
+		p.cfg.IsInitConfig = true
+	}
+
+	// Only the components for the distro of the cluster are pulled when deploying
+	if err := p.resolveClusterDistro(); err != nil {
+		return nil, err
+	}
+
+	selectedComponents := map[string]bool{}
+	selectedImages := map[string]bool{}
+	var componentNames, imageTags []string
+	for _, component := range p.getValidComponents() {
+		componentNames = append(componentNames, component.Name)
+		selectedComponents[component.Name] = true
+
+		for _, image := range component.Images {
+			tag, err := images.TarballTag(image)
+			if err != nil {
+				return nil, err
+			}
+			imageTags = append(imageTags, tag.String())
+			selectedImages[tag.Name()] = true
+		}
+	}
+
+	// Record what is deliberately left behind, only these contents are allowed to be missing when validating checksums
+	p.unpulledComponents = map[string]bool{}
+	p.unpulledImages = map[string]bool{}
+	for _, component := range p.cfg.Pkg.Components {
+		if selectedComponents[component.Name] {
+			continue
+		}
+		p.unpulledComponents[component.Name] = true
+
+		for _, image := range component.Images {
+			tag, err := images.TarballTag(image)
+			if err != nil {
+				return nil, err
+			}
+			if !selectedImages[tag.Name()] {
+				p.unpulledImages[tag.Name()] = true
+			}
+		}
+	}
+
+	pulled := map[v1.Hash]bool{}
+	for _, layer := range metadataLayers {
+		pulled[layer.Digest] = true
+	}
+
+	var layers []v1.Descriptor
+	for _, layer := range pkg.ComponentLayers(componentNames, imageTags) {
+		if !pulled[layer.Digest] {
+			layers = append(layers, layer)
+		}
+	}
+
+	return layers, nil
+}
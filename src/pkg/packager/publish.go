@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/packager/oci"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/mholt/archiver/v3"
+)
+
+// Publish pushes a Zarf package to an OCI registry (or writes it to a local OCI image layout) as a layered artifact.
+func (p *Packager) Publish() error {
+	message.Debug("packager.Publish()")
+
+	opts := p.cfg.PublishOpts
+
+	// Reuse the deploy logic to fetch and extract the package into the temp directory
+	p.cfg.DeployOpts.PackagePath = opts.PackagePath
+	if err := p.loadZarfPkg(); err != nil {
+		return fmt.Errorf("unable to load the package: %w", err)
+	}
+
+	layers, err := p.getPublishLayers()
+	if err != nil {
+		return fmt.Errorf("unable to prepare the package layers: %w", err)
+	}
+
+	tag := p.getPublishTag()
+	annotations := map[string]string{
+		oci.TitleAnnotation:       p.cfg.Pkg.Metadata.Name,
+		oci.DescriptionAnnotation: p.cfg.Pkg.Metadata.Description,
+		oci.RefNameAnnotation:     fmt.Sprintf("%s:%s", p.cfg.Pkg.Metadata.Name, tag),
+	}
+	if p.cfg.Pkg.Metadata.Version != "" {
+		annotations[oci.VersionAnnotation] = p.cfg.Pkg.Metadata.Version
+	}
+
+	// Anything that is not an oci:// URL is treated as a local OCI image layout directory
+	if !oci.IsOCIURL(opts.Repository) {
+		if _, err := oci.WriteLayout(opts.Repository, layers, annotations); err != nil {
+			return err
+		}
+
+		message.Infof("To deploy this package run:\n\n    zarf package deploy %s\n", opts.Repository)
+		return nil
+	}
+
+	repo, err := oci.ParseRepository(opts.Repository, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	ref, err := oci.ParseReference(fmt.Sprintf("%s/%s:%s", repo, p.cfg.Pkg.Metadata.Name, tag), opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	digest, err := oci.Push(ref, layers, annotations)
+	if err != nil {
+		return err
+	}
+
+	message.Infof("To deploy this package run:\n\n    zarf package deploy %s%s\n\nor pin it by digest with --shasum %s", oci.URLPrefix, ref, digest.Hex)
+	return nil
+}
+
+// getPublishTag returns the tag a package is published under, the version (when set) and the architecture.
+func (p *Packager) getPublishTag() string {
+	arch := p.cfg.Pkg.Build.Architecture
+	if arch == "" {
+		arch = config.GetArch()
+	}
+
+	if p.cfg.Pkg.Metadata.Version == "" {
+		return arch
+	}
+
+	// OCI tags do not allow the '+' used by semver build metadata
+	return fmt.Sprintf("%s-%s", strings.ReplaceAll(p.cfg.Pkg.Metadata.Version, "+", "_"), arch)
+}
+
+// getPublishLayers splits the extracted package into layers, files are published as-is and each directory as a tarball.
+// The components directory and the images tarball are split further so that each component and image is its own layer.
+func (p *Packager) getPublishLayers() ([]oci.Layer, error) {
+	var layers []oci.Layer
+
+	entries, err := os.ReadDir(p.tmp.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage the directory tarballs next to the (already listed) package contents so they are cleaned up with the temp paths
+	stagingDir := filepath.Join(p.tmp.Base, ".publish")
+	if err := utils.CreateDirectory(stagingDir, 0700); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		entryPath := filepath.Join(p.tmp.Base, entry.Name())
+
+		// Remote packages are downloaded into the temp directory, skip the archive itself
+		if entryPath == p.cfg.DeployOpts.PackagePath {
+			continue
+		}
+
+		if entryPath == p.tmp.Images {
+			imageLayers, err := oci.SplitImageTarball(entryPath, stagingDir)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, imageLayers...)
+			continue
+		}
+
+		if !entry.IsDir() {
+			layers = append(layers, oci.Layer{Path: entryPath, Title: entry.Name(), MediaType: oci.ZarfLayerMediaTypeBlob})
+			continue
+		}
+
+		if entryPath != p.tmp.Components {
+			layer, err := tarPublishLayer(entryPath, entry.Name(), stagingDir)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, layer)
+			continue
+		}
+
+		components, err := os.ReadDir(p.tmp.Components)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, component := range components {
+			layer, err := tarPublishLayer(filepath.Join(p.tmp.Components, component.Name()), path.Join(oci.ComponentsDir, component.Name()), stagingDir)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, layer)
+		}
+	}
+
+	return layers, nil
+}
+
+// tarPublishLayer archives a directory (including the directory itself) into the staging directory.
+func tarPublishLayer(dirPath string, relativePath string, stagingDir string) (oci.Layer, error) {
+	title := relativePath + ".tar"
+	tarPath := filepath.Join(stagingDir, filepath.FromSlash(title))
+
+	if err := utils.CreateDirectory(filepath.Dir(tarPath), 0700); err != nil {
+		return oci.Layer{}, err
+	}
+
+	if err := archiver.Archive([]string{dirPath}, tarPath); err != nil {
+		return oci.Layer{}, fmt.Errorf("unable to archive %s: %w", relativePath, err)
+	}
+
+	return oci.Layer{Path: tarPath, Title: title, MediaType: oci.ZarfLayerMediaTypeTar}, nil
+}
@@ -5,17 +5,14 @@
 package packager
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/internal/cluster"
 	"github.com/defenseunicorns/zarf/src/internal/packager/helm"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/types"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/strings/slices"
 )
 
@@ -32,27 +29,33 @@ func (p *Packager) Remove(packageName string) error {
 		}
 	}
 
-	// Get the secret for the deployed package
-	secretName := config.ZarfPackagePrefix + packageName
-	packageSecret, err := p.cluster.Kube.GetSecret("zarf", secretName)
+	// Get the record of the deployed package
+	packages, err := p.cluster.GetDeployedPackage(packageName)
 	if err != nil {
-		spinner.Errorf(err, "Unable to get the secret for the package we are attempting to remove")
+		spinner.Errorf(err, "Unable to load the record of the package we are attempting to remove")
 
 		return err
 	}
 
-	// Get the list of components the package had deployed
-	packages := types.DeployedPackage{}
-	err = json.Unmarshal(packageSecret.Data["data"], &packages)
-	if err != nil {
-		spinner.Errorf(err, "Unable to load the secret for the package we are attempting to remove")
+	// If components were provided; just remove the things we were asked to remove and return
+	requestedComponents := strings.Split(p.cfg.DeployOpts.Components, ",")
+	partialRemoval := len(requestedComponents) > 0 && requestedComponents[0] != ""
 
-		return err
+	// Components that stay deployed should not lose the components they depend on
+	if partialRemoval && !p.cfg.RemoveOpts.Force {
+		if err := checkRemainingDependents(packages, requestedComponents); err != nil {
+			spinner.Errorf(err, "Unable to remove the requested components")
+
+			return err
+		}
 	}
 
-	// If components were provided; just remove the things we were asked to remove and return
-	requestedComponents := strings.Split(p.cfg.DeployOpts.Components, ",")
-	if len(requestedComponents) > 0 && requestedComponents[0] != "" {
+	if err := p.cluster.MarkPackageRemoving(packageName); err != nil {
+		message.Debugf("Unable to mark the package %s as being removed: %s", packageName, err.Error())
+	}
+
+	if partialRemoval {
+		var removedComponents []string
 		for i := len(packages.DeployedComponents) - 1; i >= 0; i-- {
 			installedComponent := packages.DeployedComponents[i]
 
@@ -64,24 +67,25 @@ func (p *Packager) Remove(packageName string) error {
 
 				// Remove the component we just removed from the array
 				packages.DeployedComponents = append(packages.DeployedComponents[:i], packages.DeployedComponents[i+1:]...)
+				removedComponents = append(removedComponents, installedComponent.Name)
 			}
+		}
 
-			if len(packages.DeployedComponents) == 0 {
-				// All the installed components were deleted, there for this package is no longer actually deployed
-				_ = p.cluster.Kube.DeleteSecret(packageSecret)
-			} else {
-				// Save the new secret with the removed components removed from the secret
-				newPackageSecret := p.cluster.Kube.GenerateSecret("zarf", secretName, corev1.SecretTypeOpaque)
-				newPackageSecret.Labels["package-deploy-info"] = p.cfg.Pkg.Metadata.Name
-				newPackageSecretData, _ := json.Marshal(packages)
-				newPackageSecret.Data["data"] = newPackageSecretData
-				err = p.cluster.Kube.ReplaceSecret(newPackageSecret)
-				if err != nil {
-					message.Warnf("Unable to replace the %s package secret: %#v", secretName, err)
-				}
-			}
+		if len(packages.DeployedComponents) == 0 {
+			// All the installed components were deleted, there for this package is no longer actually deployed
+			_ = p.cluster.DeleteDeployedPackage(packageName)
+		} else if err := p.cluster.UpdateDeployedPackage(packages, removedComponents); err != nil {
+			// Save the package record with the removed components removed from it
+			message.Warnf("Unable to update the record of the %s package: %s", packageName, err.Error())
 		}
 	} else {
+		// Other packages may stop working without this package
+		if deployedPackages, err := p.cluster.GetDeployedZarfPackages(); err == nil {
+			if requiringPackages := getRequiringPackages(deployedPackages, packageName); len(requiringPackages) > 0 {
+				message.Warnf("The %s package is required by the deployed packages %s", packageName, strings.Join(requiringPackages, ", "))
+			}
+		}
+
 		// Loop through all the installed components and remove them
 		for i := len(packages.DeployedComponents) - 1; i >= 0; i-- {
 			installedComponent := packages.DeployedComponents[i]
@@ -100,7 +104,29 @@ func (p *Packager) Remove(packageName string) error {
 				}
 			}
 		}
-		p.cluster.Kube.DeleteSecret(packageSecret)
+		_ = p.cluster.DeleteDeployedPackage(packageName)
+	}
+
+	return nil
+}
+
+// checkRemainingDependents returns an error if a component that stays deployed depends on one of the removed components.
+func checkRemainingDependents(deployedPackage types.DeployedPackage, removedComponents []string) error {
+	deployed := make(map[string]bool)
+	for _, component := range deployedPackage.DeployedComponents {
+		deployed[component.Name] = true
+	}
+
+	for _, component := range deployedPackage.Data.Components {
+		if !deployed[component.Name] || slices.Contains(removedComponents, component.Name) {
+			continue
+		}
+
+		for _, dependency := range component.DependsOn {
+			if deployed[dependency] && slices.Contains(removedComponents, dependency) {
+				return fmt.Errorf("the %s component depends on the %s component, remove it as well or use --force", component.Name, dependency)
+			}
+		}
 	}
 
 	return nil
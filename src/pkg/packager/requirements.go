@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+)
+
+// checkPackageRequirements ensures that the packages this package requires are deployed to the cluster with a version
+// that satisfies their constraints.
+func (p *Packager) checkPackageRequirements() error {
+	message.Debugf("packager.checkPackageRequirements(%#v)", p.cfg.Pkg.Metadata.Requires)
+
+	if len(p.cfg.Pkg.Metadata.Requires) == 0 {
+		return nil
+	}
+
+	// Make sure we have access to the cluster
+	if p.cluster == nil {
+		var err error
+		p.cluster, err = cluster.NewClusterWithWait(30 * time.Second)
+		if err != nil {
+			return fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+		}
+	}
+
+	deployedPackages, err := p.cluster.GetDeployedZarfPackages()
+	if err != nil {
+		return fmt.Errorf("unable to get the deployed packages: %w", err)
+	}
+
+	return checkRequirements(p.cfg.Pkg.Metadata.Requires, deployedPackages)
+}
+
+// checkRequirements returns an error if a required package is not among the deployed packages or its deployed version
+// does not satisfy the version constraint of the requirement.
+func checkRequirements(requirements []types.ZarfPackageRequirement, deployedPackages []types.DeployedPackage) error {
+	deployedVersions := make(map[string]string)
+	for _, deployedPackage := range deployedPackages {
+		deployedVersions[deployedPackage.Name] = deployedPackage.Data.Metadata.Version
+	}
+
+	for _, requirement := range requirements {
+		deployedVersion, ok := deployedVersions[requirement.Name]
+		if !ok {
+			return fmt.Errorf("this package requires the %s package, deploy it first", requirement.Name)
+		}
+
+		if requirement.Version == "" {
+			continue
+		}
+
+		constraint, err := semver.NewConstraint(requirement.Version)
+		if err != nil {
+			return fmt.Errorf("invalid version constraint %q for the required package %s: %w", requirement.Version, requirement.Name, err)
+		}
+
+		version, err := semver.NewVersion(deployedVersion)
+		if err != nil {
+			return fmt.Errorf("this package requires the %s package at version %s but the deployed version %q is not a semantic version", requirement.Name, requirement.Version, deployedVersion)
+		}
+
+		if !constraint.Check(version) {
+			return fmt.Errorf("this package requires the %s package at version %s but version %s is deployed", requirement.Name, requirement.Version, deployedVersion)
+		}
+	}
+
+	return nil
+}
+
+// getRequiringPackages returns the names of the deployed packages that require the given package.
+func getRequiringPackages(deployedPackages []types.DeployedPackage, packageName string) []string {
+	var requiringPackages []string
+
+	for _, deployedPackage := range deployedPackages {
+		if deployedPackage.Name == packageName {
+			continue
+		}
+
+		for _, requirement := range deployedPackage.Data.Metadata.Requires {
+			if requirement.Name == packageName {
+				requiringPackages = append(requiringPackages, deployedPackage.Name)
+				break
+			}
+		}
+	}
+
+	return requiringPackages
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeployedPackage(name, version string, requires ...types.ZarfPackageRequirement) types.DeployedPackage {
+	return types.DeployedPackage{
+		Name: name,
+		Data: types.ZarfPackage{Metadata: types.ZarfMetadata{Name: name, Version: version, Requires: requires}},
+	}
+}
+
+func TestCheckRequirements(t *testing.T) {
+	deployedPackages := []types.DeployedPackage{
+		newDeployedPackage("init", "v0.24.0"),
+		newDeployedPackage("database", "1.4.2"),
+		newDeployedPackage("legacy", "latest"),
+	}
+
+	tests := []struct {
+		name         string
+		requirements []types.ZarfPackageRequirement
+		wantErr      string
+	}{
+		{
+			name: "no requirements",
+		},
+		{
+			name:         "deployed without a version constraint",
+			requirements: []types.ZarfPackageRequirement{{Name: "legacy"}},
+		},
+		{
+			name:         "deployed version satisfies the constraint",
+			requirements: []types.ZarfPackageRequirement{{Name: "init", Version: ">= 0.23.0"}, {Name: "database", Version: "~1.4"}},
+		},
+		{
+			name:         "not deployed",
+			requirements: []types.ZarfPackageRequirement{{Name: "cache"}},
+			wantErr:      "this package requires the cache package, deploy it first",
+		},
+		{
+			name:         "deployed version does not satisfy the constraint",
+			requirements: []types.ZarfPackageRequirement{{Name: "database", Version: ">= 2.0.0"}},
+			wantErr:      "this package requires the database package at version >= 2.0.0 but version 1.4.2 is deployed",
+		},
+		{
+			name:         "deployed version is not semantic",
+			requirements: []types.ZarfPackageRequirement{{Name: "legacy", Version: "^1.0.0"}},
+			wantErr:      `this package requires the legacy package at version ^1.0.0 but the deployed version "latest" is not a semantic version`,
+		},
+		{
+			name:         "invalid constraint",
+			requirements: []types.ZarfPackageRequirement{{Name: "database", Version: "not a constraint"}},
+			wantErr:      `invalid version constraint "not a constraint" for the required package database`,
+		},
+	}
+
+	for _, tt := range tests {
+		err := checkRequirements(tt.requirements, deployedPackages)
+		if tt.wantErr == "" {
+			require.NoError(t, err, tt.name)
+			continue
+		}
+		require.ErrorContains(t, err, tt.wantErr, tt.name)
+	}
+}
+
+func TestGetRequiringPackages(t *testing.T) {
+	deployedPackages := []types.DeployedPackage{
+		newDeployedPackage("database", "1.4.2"),
+		newDeployedPackage("app", "1.0.0", types.ZarfPackageRequirement{Name: "database"}),
+		newDeployedPackage("worker", "1.0.0", types.ZarfPackageRequirement{Name: "cache"}, types.ZarfPackageRequirement{Name: "database", Version: "^1.0.0"}),
+	}
+
+	require.Equal(t, []string{"app", "worker"}, getRequiringPackages(deployedPackages, "database"))
+	require.Equal(t, []string{"worker"}, getRequiringPackages(deployedPackages, "cache"))
+	require.Empty(t, getRequiringPackages(deployedPackages, "app"))
+}
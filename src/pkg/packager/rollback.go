@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/internal/packager/helm"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+)
+
+// Rollback rolls a deployed package back to an earlier generation of its deployment (the previous one if generation
+// is 0), rolling back each helm chart to the revision recorded for that generation and restoring its package record.
+func (p *Packager) Rollback(packageName string, generation int) error {
+	message.Debugf("packager.Rollback(%s, %d)", packageName, generation)
+
+	spinner := message.NewProgressSpinner("Rolling back zarf package %s", packageName)
+	defer spinner.Stop()
+
+	var err error
+	if p.cluster == nil {
+		p.cluster, err = cluster.NewClusterWithWait(30 * time.Second)
+		if err != nil {
+			return fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+		}
+	}
+
+	current, err := p.cluster.GetDeployedPackage(packageName)
+	if err != nil {
+		return fmt.Errorf("unable to get the deployed package %s: %w", packageName, err)
+	}
+
+	history, err := p.cluster.GetPackageDeploymentHistory(packageName)
+	if err != nil {
+		return fmt.Errorf("unable to get the deployment history of %s: %w", packageName, err)
+	}
+
+	var target *types.DeployedPackage
+	var available []int
+	for idx, deployedPackage := range history {
+		if deployedPackage.Generation == current.Generation {
+			continue
+		}
+		available = append(available, deployedPackage.Generation)
+
+		if (generation == 0 && deployedPackage.Generation < current.Generation) || deployedPackage.Generation == generation {
+			target = &history[idx]
+		}
+	}
+
+	if generation != 0 && generation == current.Generation {
+		return fmt.Errorf("generation %d of %s is already deployed", generation, packageName)
+	}
+
+	if target == nil {
+		if generation == 0 {
+			return fmt.Errorf("there is no earlier generation of %s to roll back to", packageName)
+		}
+		return fmt.Errorf("generation %d of %s was not found, available generations are %v", generation, packageName, available)
+	}
+
+	targetCharts := map[types.InstalledChart]bool{}
+	for _, component := range target.DeployedComponents {
+		for _, installedChart := range component.InstalledCharts {
+			targetCharts[types.InstalledChart{Namespace: installedChart.Namespace, ChartName: installedChart.ChartName}] = true
+		}
+	}
+
+	// Releases of the target generation can be gone (i.e. removed with zarf package remove --components), check them
+	// before anything is uninstalled so a missing one cannot leave the rollback half done
+	var rollbackCharts []types.InstalledChart
+	for idx, component := range target.DeployedComponents {
+		var existingCharts []types.InstalledChart
+		for _, installedChart := range component.InstalledCharts {
+			spinner.Updatef("Checking chart (%s) from the (%s) component", installedChart.ChartName, component.Name)
+			helmCfg := helm.Helm{}
+			exists, err := helmCfg.ReleaseExists(installedChart.Namespace, installedChart.ChartName, spinner)
+			if err != nil {
+				return fmt.Errorf("unable to check the helm chart (%s) in the namespace (%s): %w", installedChart.ChartName, installedChart.Namespace, err)
+			}
+			if !exists {
+				message.Warnf("The helm chart (%s) from the (%s) component is no longer installed in the namespace (%s), skipping it", installedChart.ChartName, component.Name, installedChart.Namespace)
+				continue
+			}
+			rollbackCharts = append(rollbackCharts, installedChart)
+			existingCharts = append(existingCharts, installedChart)
+		}
+
+		// The restored record must only list the charts that are actually installed
+		target.DeployedComponents[idx].InstalledCharts = existingCharts
+	}
+
+	// Charts installed after the target generation are removed, in the reverse order they were installed
+	for i := len(current.DeployedComponents) - 1; i >= 0; i-- {
+		component := current.DeployedComponents[i]
+		for _, installedChart := range component.InstalledCharts {
+			if targetCharts[types.InstalledChart{Namespace: installedChart.Namespace, ChartName: installedChart.ChartName}] {
+				continue
+			}
+
+			spinner.Updatef("Uninstalling chart (%s) from the (%s) component", installedChart.ChartName, component.Name)
+			helmCfg := helm.Helm{}
+			if err := helmCfg.RemoveChart(installedChart.Namespace, installedChart.ChartName, spinner); err != nil {
+				return fmt.Errorf("unable to remove the helm chart (%s) from the namespace (%s): %w", installedChart.ChartName, installedChart.Namespace, err)
+			}
+		}
+	}
+
+	for _, installedChart := range rollbackCharts {
+		if installedChart.Revision == 0 {
+			message.Warnf("No revision was recorded for the helm chart (%s), rolling it back to its previous revision", installedChart.ChartName)
+		}
+
+		spinner.Updatef("Rolling back chart (%s)", installedChart.ChartName)
+		helmCfg := helm.Helm{}
+		if err := helmCfg.RollbackChart(installedChart.Namespace, installedChart.ChartName, installedChart.Revision, spinner); err != nil {
+			return fmt.Errorf("unable to roll back the helm chart (%s) in the namespace (%s): %w", installedChart.ChartName, installedChart.Namespace, err)
+		}
+	}
+
+	// The restored record becomes the newest generation so the rollback itself can be rolled back
+	if err := p.cluster.RecordPackageDeployment(target.Data, target.DeployedComponents); err != nil {
+		return fmt.Errorf("unable to record the package deployment: %w", err)
+	}
+
+	spinner.Successf("Rolled back %s from generation %d to generation %d", packageName, current.Generation, target.Generation)
+	return nil
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/sigstore/cosign/pkg/cosign"
+)
+
+// signPackage signs the zarf.yaml, which holds the aggregate checksum of the package contents, with the configured
+// signing key.
+func (p *Packager) signPackage() error {
+	message.Debugf("packager.signPackage(%s)", p.cfg.CreateOpts.SigningKeyPath)
+
+	spinner := message.NewProgressSpinner("Signing the package with %s", p.cfg.CreateOpts.SigningKeyPath)
+	defer spinner.Stop()
+
+	passFunc := func(_ bool) ([]byte, error) {
+		if p.cfg.CreateOpts.SigningKeyPassword != "" {
+			return []byte(p.cfg.CreateOpts.SigningKeyPassword), nil
+		}
+		return cosign.GetPassFromTerm(false)
+	}
+
+	signaturePath := filepath.Join(p.tmp.Base, config.ZarfYAMLSignature)
+	if err := utils.CosignSignBlob(p.tmp.ZarfYaml, signaturePath, p.cfg.CreateOpts.SigningKeyPath, passFunc); err != nil {
+		return err
+	}
+
+	spinner.Successf("Package signed with %s", p.cfg.CreateOpts.SigningKeyPath)
+	return nil
+}
+
+// verifyPackageSignature verifies the signature of the zarf.yaml with the configured public key. A signed package
+// without a public key is rejected unless signature validation is explicitly skipped.
+func (p *Packager) verifyPackageSignature() error {
+	message.Debugf("packager.verifyPackageSignature(%s)", p.cfg.DeployOpts.PublicKeyPath)
+
+	signaturePath := filepath.Join(p.tmp.Base, config.ZarfYAMLSignature)
+
+	if p.cfg.DeployOpts.PublicKeyPath == "" {
+		if utils.InvalidPath(signaturePath) {
+			return nil
+		}
+		if !p.cfg.DeployOpts.SkipSignatureValidation {
+			return fmt.Errorf("the package is signed but no public key was provided, use --key to verify it or --skip-signature-validation to ignore")
+		}
+		message.Warn("The package is signed but no public key was provided, skipping signature verification")
+		return nil
+	}
+
+	if utils.InvalidPath(signaturePath) {
+		return fmt.Errorf("a public key was provided but the package is not signed")
+	}
+
+	if err := utils.CosignVerifyBlob(p.tmp.ZarfYaml, signaturePath, p.cfg.DeployOpts.PublicKeyPath); err != nil {
+		return fmt.Errorf("unable to verify the package signature: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPackageSignature(t *testing.T) {
+	tests := []struct {
+		name    string
+		signed  bool
+		opts    types.ZarfDeployOptions
+		wantErr string
+	}{
+		{
+			name: "unsigned package without a key",
+		},
+		{
+			name:    "signed package without a key",
+			signed:  true,
+			wantErr: "the package is signed but no public key was provided",
+		},
+		{
+			name:   "signed package with signature validation skipped",
+			signed: true,
+			opts:   types.ZarfDeployOptions{SkipSignatureValidation: true},
+		},
+		{
+			name:    "unsigned package with a key",
+			opts:    types.ZarfDeployOptions{PublicKeyPath: "cosign.pub"},
+			wantErr: "a public key was provided but the package is not signed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			p := &Packager{
+				cfg: &types.PackagerConfig{DeployOpts: tt.opts},
+				tmp: types.TempPaths{
+					Base:     base,
+					ZarfYaml: filepath.Join(base, config.ZarfYAML),
+				},
+			}
+			require.NoError(t, os.WriteFile(p.tmp.ZarfYaml, []byte("kind: ZarfPackageConfig"), 0600))
+			if tt.signed {
+				require.NoError(t, os.WriteFile(filepath.Join(base, config.ZarfYAMLSignature), []byte("signature"), 0600))
+			}
+
+			err := p.verifyPackageSignature()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
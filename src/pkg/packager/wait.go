@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/pkg/k8s"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+)
+
+// waitForComponentConditions blocks until every wait condition of the component is met, or returns an error as soon
+// as one of them times out.
+func (p *Packager) waitForComponentConditions(waits []types.ZarfComponentWait) error {
+	message.Debugf("packager.waitForComponentConditions(%#v)", waits)
+
+	// Make sure we have access to the cluster
+	if p.cluster == nil {
+		var err error
+		p.cluster, err = cluster.NewClusterWithWait(30 * time.Second)
+		if err != nil {
+			return fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+		}
+	}
+
+	for _, wait := range waits {
+		target := k8s.WaitCondition{
+			Kind:      wait.Kind,
+			Name:      wait.Name,
+			Namespace: wait.Namespace,
+			Selector:  wait.Selector,
+			Condition: wait.Condition,
+			JSONPath:  wait.JSONPath,
+			Value:     wait.Value,
+		}
+
+		if wait.TimeoutSeconds < 1 {
+			wait.TimeoutSeconds = 300
+		}
+		timeout := time.Duration(wait.TimeoutSeconds) * time.Second
+
+		// Each wait gets its own spinner, so stop it here rather than deferring it to the end of the loop
+		spinner := message.NewProgressSpinner("Waiting for %s (timeout: %d seconds)", target, wait.TimeoutSeconds)
+
+		progress := func(status string) {
+			spinner.Updatef("%s", status)
+		}
+
+		if err := p.cluster.Kube.WaitForCondition(target, timeout, progress); err != nil {
+			spinner.Errorf(err, "Failed waiting for %s", target)
+			spinner.Stop()
+			return err
+		}
+
+		spinner.Successf("%s is ready", target)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"math/big"
 	"net"
 	"time"
@@ -60,6 +61,21 @@ func GeneratePKI(host string, dnsNames ...string) k8s.GeneratedPKI {
 	return results
 }
 
+// GetCertExpiration returns when the first certificate of a PEM encoded chain expires.
+func GetCertExpiration(certPEM []byte) (time.Time, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return time.Time{}, errors.New("unable to decode the PEM certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter, nil
+}
+
 // newCertificate creates a new template.
 func newCertificate(validFor time.Duration) *x509.Certificate {
 	notBefore := time.Now()
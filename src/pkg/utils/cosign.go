@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic helper functions.
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/sigstore/cosign/pkg/cosign"
+	sigs "github.com/sigstore/cosign/pkg/signature"
+)
+
+// CosignSignBlob signs the blob at the given path with a cosign private key and writes the base64 encoded signature
+// to sigPath, the same format produced by 'cosign sign-blob'.
+func CosignSignBlob(blobPath string, sigPath string, keyPath string, passFunc cosign.PassFunc) error {
+	message.Debugf("utils.CosignSignBlob(%s, %s, %s)", blobPath, sigPath, keyPath)
+
+	signer, err := sigs.SignerVerifierFromKeyRef(context.TODO(), keyPath, passFunc)
+	if err != nil {
+		return fmt.Errorf("unable to load the signing key %s: %w", keyPath, err)
+	}
+
+	blob, err := os.ReadFile(blobPath)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.SignMessage(bytes.NewReader(blob))
+	if err != nil {
+		return fmt.Errorf("unable to sign %s: %w", blobPath, err)
+	}
+
+	return os.WriteFile(sigPath, []byte(base64.StdEncoding.EncodeToString(sig)), 0644)
+}
+
+// CosignVerifyBlob verifies the base64 encoded signature at sigPath of the blob at the given path with a cosign public key.
+func CosignVerifyBlob(blobPath string, sigPath string, keyPath string) error {
+	message.Debugf("utils.CosignVerifyBlob(%s, %s, %s)", blobPath, sigPath, keyPath)
+
+	verifier, err := sigs.PublicKeyFromKeyRef(context.TODO(), keyPath)
+	if err != nil {
+		return fmt.Errorf("unable to load the public key %s: %w", keyPath, err)
+	}
+
+	blob, err := os.ReadFile(blobPath)
+	if err != nil {
+		return err
+	}
+
+	encodedSig, err := os.ReadFile(sigPath)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encodedSig)))
+	if err != nil {
+		return fmt.Errorf("unable to decode the signature %s: %w", sigPath, err)
+	}
+
+	return verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(blob))
+}
@@ -7,6 +7,7 @@ package utils
 import (
 	"fmt"
 	"hash/crc32"
+	"strings"
 
 	"github.com/distribution/distribution/v3/reference"
 )
@@ -36,6 +37,11 @@ func SwapHost(src string, targetHost string) (string, error) {
 	return fmt.Sprintf("%s/%s-%d%s", targetHost, image.Path, checksum, image.TagOrDigest), nil
 }
 
+// IsImageOnHost returns true if the image reference already points at the given host.
+func IsImageOnHost(src string, targetHost string) bool {
+	return strings.HasPrefix(src, strings.TrimSuffix(targetHost, "/")+"/")
+}
+
 // SwapHostWithoutChecksum Perform base url replacement but avoids adding a checksum of the original url.
 func SwapHostWithoutChecksum(src string, targetHost string) (string, error) {
 	image, err := parseImageURL(src)
@@ -172,6 +172,23 @@ func GetFinalExecutablePath() (string, error) {
 	return linkedPath, err
 }
 
+// GetDirSize walks through all files and directories in the provided path and returns the total size in bytes.
+func GetDirSize(path string) (int64, error) {
+	var dirSize int64
+
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			dirSize += info.Size()
+		}
+		return nil
+	})
+
+	return dirSize, err
+}
+
 // SplitFile splits a file into multiple parts by the given size.
 func SplitFile(path string, chunkSizeBytes int) (chunks [][]byte, sha256sum string, err error) {
 	var file []byte
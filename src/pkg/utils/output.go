@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic helper functions.
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+const (
+	// OutputJSON formats command output as JSON.
+	OutputJSON = "json"
+	// OutputYAML formats command output as YAML.
+	OutputYAML = "yaml"
+)
+
+// ValidateOutputFormat returns an error if the format is not a supported --output format.
+func ValidateOutputFormat(format string) error {
+	if format != OutputJSON && format != OutputYAML {
+		return fmt.Errorf("unsupported output format %q, must be %s or %s", format, OutputJSON, OutputYAML)
+	}
+	return nil
+}
+
+// PrintStructured writes data to stdout as JSON or YAML for other tools to consume. All other console output goes to
+// stderr, so stdout only holds this document.
+func PrintStructured(format string, data any) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+
+	// YAML is converted from the JSON so both formats use the same field names
+	text, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal the output: %w", err)
+	}
+
+	if format == OutputYAML {
+		if text, err = k8syaml.JSONToYAML(text); err != nil {
+			return fmt.Errorf("unable to marshal the output: %w", err)
+		}
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, strings.TrimSpace(string(text)))
+	return err
+}
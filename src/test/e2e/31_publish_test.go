@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for Zarf.
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishAndDeployLayout(t *testing.T) {
+	t.Log("E2E: Publish a package to an OCI layout and deploy it")
+	e2e.setup(t)
+	defer e2e.teardown(t)
+
+	path := fmt.Sprintf("build/zarf-package-component-scripts-%s.tar.zst", e2e.arch)
+	layout := filepath.Join(t.TempDir(), "component-scripts")
+
+	deployArtifacts := []string{
+		"test-deploy-before.txt",
+		"test-deploy-after.txt",
+	}
+	e2e.cleanFiles(deployArtifacts...)
+	defer e2e.cleanFiles(deployArtifacts...)
+
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "publish", path, layout)
+	require.NoError(t, err, stdOut, stdErr)
+	require.FileExists(t, filepath.Join(layout, "oci-layout"))
+
+	// The layout digest has to match when a shasum is given
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", layout, "--confirm", "--components=deploy", "--shasum=0000")
+	require.Error(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "does not match provided shasum")
+
+	// Only the selected component is pulled from the layout and deployed
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", layout, "--confirm", "--components=deploy")
+	require.NoError(t, err, stdOut, stdErr)
+
+	for _, artifact := range deployArtifacts {
+		require.FileExists(t, artifact)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for Zarf.
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDifferentialPackage(t *testing.T) {
+	t.Log("E2E: Create and deploy a differential package")
+	e2e.setupWithCluster(t)
+	defer e2e.teardown(t)
+
+	buildDir := t.TempDir()
+	basePath := filepath.Join(buildDir, fmt.Sprintf("zarf-package-differential-%s-v0.1.0.tar.zst", e2e.arch))
+	differentialPath := filepath.Join(buildDir, fmt.Sprintf("zarf-package-differential-%s-v0.2.0.tar.zst", e2e.arch))
+
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "create", "examples/differential", "-o", buildDir, "--confirm",
+		"--set", "PACKAGE_VERSION=v0.1.0", "--set", "PODINFO_VERSION=6.2.0")
+	require.NoError(t, err, stdOut, stdErr)
+
+	// A differential package has to be a newer version than the package it is built against
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "create", "examples/differential", "-o", buildDir, "--confirm",
+		"--set", "PACKAGE_VERSION=v0.1.0", "--set", "PODINFO_VERSION=6.3.0", "--differential", basePath)
+	require.Error(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "has the same version as the differential package")
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "create", "examples/differential", "-o", buildDir, "--confirm",
+		"--set", "PACKAGE_VERSION=v0.2.0", "--set", "PODINFO_VERSION=6.3.0", "--differential", basePath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	// Only the unchanged image with an immutable tag is left out of the differential package
+	decompressPath := filepath.Join(t.TempDir(), "differential")
+	stdOut, stdErr, err = e2e.execZarfCommand("tools", "archiver", "decompress", differentialPath, decompressPath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	var differentialPkg types.ZarfPackage
+	require.NoError(t, utils.ReadYaml(filepath.Join(decompressPath, "zarf.yaml"), &differentialPkg))
+	require.True(t, differentialPkg.Build.Differential)
+	require.Equal(t, "v0.1.0", differentialPkg.Build.DifferentialPackageVersion)
+	require.Equal(t, []string{"ghcr.io/stefanprodan/podinfo:6.1.0"}, differentialPkg.Build.DifferentialMissing)
+	require.Equal(t, []string{"ghcr.io/stefanprodan/podinfo:6.3.0", "ghcr.io/stefanprodan/podinfo:latest"}, differentialPkg.Components[0].Images)
+
+	// The differential package can only be deployed on top of the version it was built against
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", differentialPath, "--confirm")
+	require.Error(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "but the package is not deployed to the cluster")
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", basePath, "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", differentialPath, "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "differential", "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for Zarf.
+package test
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRollback(t *testing.T) {
+	t.Log("E2E: Roll back a package to an earlier generation")
+	e2e.setupWithCluster(t)
+	defer e2e.teardown(t)
+
+	path := fmt.Sprintf("build/zarf-package-test-helm-local-chart-%s.tar.zst", e2e.arch)
+
+	deployedRevision := func() string {
+		out, err := exec.Command("kubectl", "get", "secret", "-n", "local-chart", "-l", "owner=helm,name=local-demo,status=deployed",
+			"-o", "jsonpath={.items[*].metadata.labels.version}").Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(out))
+	}
+
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "deploy", path, "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+
+	// A single deployment has nothing to roll back to
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "rollback", "test-helm-local-chart", "--confirm")
+	require.Error(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "there is no earlier generation of test-helm-local-chart to roll back to")
+
+	// Deploying again upgrades the chart to its second revision
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", path, "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+	require.Equal(t, "2", deployedRevision())
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "rollback", "test-helm-local-chart", "--confirm", "--generation=99")
+	require.Error(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "generation 99 of test-helm-local-chart was not found")
+
+	// Rolling back creates a new chart revision from the one recorded for the first generation
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "rollback", "test-helm-local-chart", "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "Rolled back test-helm-local-chart from generation 2 to generation 1")
+	require.Equal(t, "3", deployedRevision())
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "test-helm-local-chart", "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+}
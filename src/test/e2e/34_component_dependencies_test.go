@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for Zarf.
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentDependencies(t *testing.T) {
+	t.Log("E2E: Component dependencies")
+	e2e.setupWithCluster(t)
+	defer e2e.teardown(t)
+
+	path := fmt.Sprintf("build/zarf-package-component-dependencies-%s.tar.zst", e2e.arch)
+
+	deployArtifacts := []string{
+		"test-dependency-base.txt",
+		"test-dependency-app.txt",
+	}
+	e2e.cleanFiles(deployArtifacts...)
+	defer e2e.cleanFiles(deployArtifacts...)
+
+	// The app component fails unless the base component it depends on is deployed before it
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "deploy", path, "--confirm", "--components=app")
+	require.NoError(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "Including the base component that the app component depends on")
+
+	for _, artifact := range deployArtifacts {
+		require.FileExists(t, artifact)
+	}
+
+	// The base component cannot be removed while the app component still depends on it
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "component-dependencies", "--confirm", "--components=base")
+	require.Error(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "the app component depends on the base component")
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "component-dependencies", "--confirm", "--components=app,base")
+	require.NoError(t, err, stdOut, stdErr)
+}
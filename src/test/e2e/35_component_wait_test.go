@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for Zarf.
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentWait(t *testing.T) {
+	t.Log("E2E: Component wait conditions")
+	e2e.setupWithCluster(t)
+	defer e2e.teardown(t)
+
+	path := fmt.Sprintf("build/zarf-package-component-wait-%s.tar.zst", e2e.arch)
+
+	// The deployment is only reported ready once it is available and its pod is running
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "deploy", path, "--confirm", "--components=ready")
+	require.NoError(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "Deployment/ready-zarf-wait-test in namespace component-wait is ready")
+	require.Contains(t, stdErr, "Pod (app=ready-zarf-wait-test) in namespace component-wait is ready")
+
+	// A pod that never becomes ready fails the deployment once its wait times out
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", path, "--confirm", "--components=never-ready")
+	require.Error(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "timed out after 15s waiting for Pod/never-ready-zarf-wait-test in namespace component-wait")
+
+	// The failed component is merged into the record of the package without becoming a new generation
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "list", "--output", "json")
+	require.NoError(t, err, stdOut, stdErr)
+
+	var deployedPackages []types.DeployedPackage
+	require.NoError(t, json.Unmarshal([]byte(stdOut), &deployedPackages))
+
+	var record *types.DeployedPackage
+	for idx := range deployedPackages {
+		if deployedPackages[idx].Name == "component-wait" {
+			record = &deployedPackages[idx]
+		}
+	}
+	require.NotNil(t, record)
+	require.Equal(t, 1, record.Generation)
+	require.Len(t, record.DeployedComponents, 2)
+	require.Equal(t, "ready", record.DeployedComponents[0].Name)
+	require.Empty(t, record.DeployedComponents[0].Status)
+	require.Equal(t, "never-ready", record.DeployedComponents[1].Name)
+	require.Equal(t, types.ComponentStatusFailed, record.DeployedComponents[1].Status)
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "component-wait", "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+}
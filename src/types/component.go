@@ -25,6 +25,9 @@ type ZarfComponent struct {
 	// Note: ignores default and required flags
 	Group string `json:"group,omitempty" jsonschema:"description=Create a user selector field based on all components in the same group"`
 
+	// DependsOn lists the components that need to be deployed before this component
+	DependsOn []string `json:"dependsOn,omitempty" jsonschema:"description=Names of the components this component needs; they are deployed first and included automatically when this component is selected"`
+
 	//Path to cosign publickey for signed online resources
 	CosignKeyPath string `json:"cosignKeyPath,omitempty" jsonschema:"description=Specify a path to a public key to validate signed online resources"`
 
@@ -51,6 +54,9 @@ type ZarfComponent struct {
 
 	// Data packages to push into a running cluster
 	DataInjections []ZarfDataInjection `json:"dataInjections,omitempty" jsonschema:"description=Datasets to inject into a pod in the target cluster"`
+
+	// Wait lists resource conditions to check after the charts and manifests have been deployed
+	Wait []ZarfComponentWait `json:"wait,omitempty" jsonschema:"description=Resource conditions to wait for after the charts and manifests of this component are deployed"`
 }
 
 // ZarfComponentOnlyTarget filters a component to only show it for a given local OS and cluster.
@@ -62,7 +68,7 @@ type ZarfComponentOnlyTarget struct {
 // ZarfComponentOnlyCluster represents the architecture and K8s cluster distribution to filter on.
 type ZarfComponentOnlyCluster struct {
 	Architecture string   `json:"architecture,omitempty" jsonschema:"description=Only create and deploy to clusters of the given architecture,enum=amd64,enum=arm64"`
-	Distros      []string `json:"distros,omitempty" jsonschema:"description=Future use"`
+	Distros      []string `json:"distros,omitempty" jsonschema:"description=Only deploy to clusters of the given distros (i.e. k3s or eks); prefix a distro with ! to exclude it and use * as a wildcard"`
 }
 
 // ZarfFile defines a file to deploy.
@@ -76,15 +82,16 @@ type ZarfFile struct {
 
 // ZarfChart defines a helm chart to be deployed.
 type ZarfChart struct {
-	Name        string   `json:"name" jsonschema:"description=The name of the chart to deploy; this should be the name of the chart as it is installed in the helm repo"`
-	ReleaseName string   `json:"releaseName,omitempty" jsonschema:"description=The name of the release to create; defaults to the name of the chart"`
-	URL         string   `json:"url,omitempty" jsonschema:"oneof_required=url,description=The URL of the chart repository or git url if the chart is using a git repo instead of helm repo"`
-	Version     string   `json:"version" jsonschema:"description=The version of the chart to deploy; for git-based charts this is also the tag of the git repo"`
-	Namespace   string   `json:"namespace" jsonschema:"description=The namespace to deploy the chart to"`
-	ValuesFiles []string `json:"valuesFiles,omitempty" jsonschema:"description=List of values files to include in the package; these will be merged together"`
-	GitPath     string   `json:"gitPath,omitempty" jsonschema:"description=The path to the chart in the repo if using a git repo instead of a helm repo"`
-	LocalPath   string   `json:"localPath,omitempty" jsonschema:"oneof_required=localPath,description=The path to the chart folder"`
-	NoWait      bool     `json:"noWait,omitempty" jsonschema:"description=Wait for chart resources to be ready before continuing"`
+	Name           string   `json:"name" jsonschema:"description=The name of the chart to deploy; this should be the name of the chart as it is installed in the helm repo"`
+	ReleaseName    string   `json:"releaseName,omitempty" jsonschema:"description=The name of the release to create; defaults to the name of the chart"`
+	URL            string   `json:"url,omitempty" jsonschema:"oneof_required=url,description=The URL of the chart repository; the oci:// URL of the chart or git url if the chart is using a git repo instead of helm repo"`
+	Version        string   `json:"version" jsonschema:"description=The version of the chart to deploy; for git-based charts this is also the tag of the git repo"`
+	Namespace      string   `json:"namespace" jsonschema:"description=The namespace to deploy the chart to"`
+	ValuesFiles    []string `json:"valuesFiles,omitempty" jsonschema:"description=List of values files to include in the package; these will be merged together"`
+	GitPath        string   `json:"gitPath,omitempty" jsonschema:"description=The path to the chart in the repo if using a git repo instead of a helm repo"`
+	LocalPath      string   `json:"localPath,omitempty" jsonschema:"oneof_required=localPath,description=The path to the chart folder"`
+	NoWait         bool     `json:"noWait,omitempty" jsonschema:"description=Wait for chart resources to be ready before continuing"`
+	PushToRegistry bool     `json:"pushToRegistry,omitempty" jsonschema:"description=Push the chart to the Zarf registry as an OCI artifact during deploy so in-cluster tools can reference it"`
 }
 
 // ZarfManifest defines raw manifests Zarf will deploy as a helm chart.
@@ -118,9 +125,23 @@ type ZarfContainerTarget struct {
 
 // ZarfDataInjection is a data-injection definition.
 type ZarfDataInjection struct {
-	Source   string              `json:"source" jsonschema:"description=A path to a local folder or file to inject into the given target pod + container"`
-	Target   ZarfContainerTarget `json:"target" jsonschema:"description=The target pod + container to inject the data into"`
-	Compress bool                `json:"compress,omitempty" jsonschema:"description=Compress the data before transmitting using gzip.  Note: this requires support for tar/gzip locally and in the target image."`
+	Source         string              `json:"source" jsonschema:"description=A path to a local folder or file to inject into the given target pod + container"`
+	Target         ZarfContainerTarget `json:"target" jsonschema:"description=The target pod + container to inject the data into"`
+	Compress       bool                `json:"compress,omitempty" jsonschema:"description=Compress the data before transmitting using gzip.  Note: this requires support for tar/gzip in the target image."`
+	TimeoutSeconds int                 `json:"timeoutSeconds,omitempty" jsonschema:"description=Timeout in seconds to wait for the data injection to complete (default 900)"`
+	MaxRetries     int                 `json:"maxRetries,omitempty" jsonschema:"description=Maximum number of attempts to inject the data before failing (default 10)"`
+}
+
+// ZarfComponentWait is a resource condition to wait for during package deploy.
+type ZarfComponentWait struct {
+	Kind           string `json:"kind" jsonschema:"description=The kind of resource to wait for (i.e. Deployment or jobs.batch)"`
+	Name           string `json:"name,omitempty" jsonschema:"description=The name of the resource to wait for (cannot be used with selector)"`
+	Namespace      string `json:"namespace,omitempty" jsonschema:"description=The namespace of the resources to wait for (a name defaults to the default namespace and a selector matches all namespaces if empty)"`
+	Selector       string `json:"selector,omitempty" jsonschema:"description=A label selector matching the resources to wait for (cannot be used with name)"`
+	Condition      string `json:"condition,omitempty" jsonschema:"description=The status condition that must be True on the resources (i.e. Ready or Available or Complete)"`
+	JSONPath       string `json:"jsonPath,omitempty" jsonschema:"description=A JSONPath expression to evaluate against the resources (i.e. {.status.phase})"`
+	Value          string `json:"value,omitempty" jsonschema:"description=The value the JSONPath expression must equal (i.e. Running)"`
+	TimeoutSeconds int    `json:"timeoutSeconds,omitempty" jsonschema:"description=Timeout in seconds to wait for the resources (default 300)"`
 }
 
 // ZarfComponentImport structure for including imported Zarf components.
@@ -17,6 +17,15 @@ type ZarfState struct {
 	GitServer     GitServerInfo `json:"gitServer" jsonschema:"description=Information about the repository Zarf is configured to use"`
 	RegistryInfo  RegistryInfo  `json:"registryInfo" jsonschema:"description=Information about the registry Zarf is configured to use"`
 	LoggingSecret string        `json:"loggingSecret" jsonschema:"description=Secret value that the internal Grafana server was seeded with"`
+
+	AgentImagePolicy string `json:"agentImagePolicy,omitempty" jsonschema:"description=Mode of the Zarf Agent policy for images not delivered by a Zarf package (enforce or audit)"`
+
+	Encryption StateEncryption `json:"encryption,omitempty" jsonschema:"description=Settings used to encrypt the sensitive fields of the state at rest"`
+}
+
+// StateEncryption contains the settings used to encrypt the sensitive fields of the Zarf state at rest.
+type StateEncryption struct {
+	Provider string `json:"provider,omitempty" jsonschema:"description=Provider of the key that wraps the state data key (local)"`
 }
 
 // DeployedPackage contains information about a Zarf Package that has been deployed to a cluster
@@ -25,20 +34,35 @@ type DeployedPackage struct {
 	Name       string      `json:"name"`
 	Data       ZarfPackage `json:"data"`
 	CLIVersion string      `json:"cliVersion"`
+	Generation int         `json:"generation,omitempty"`
 
 	DeployedComponents []DeployedComponent `json:"deployedComponents"`
 }
 
+// ComponentStatusFailed is the status of a deployed component whose last deployment failed.
+const ComponentStatusFailed = "Failed"
+
 // DeployedComponent contains information about a Zarf Package Component that has been deployed to a cluster.
 type DeployedComponent struct {
-	Name            string           `json:"name"`
-	InstalledCharts []InstalledChart `json:"installedCharts"`
+	Name            string                  `json:"name"`
+	InstalledCharts []InstalledChart        `json:"installedCharts"`
+	DataInjections  []DeployedDataInjection `json:"dataInjections,omitempty"`
+	Status          string                  `json:"status,omitempty"`
+}
+
+// DeployedDataInjection contains the result of a data injection performed while deploying a component.
+type DeployedDataInjection struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
 }
 
 // InstalledChart contains information about a Helm Chart that has been deployed to a cluster.
 type InstalledChart struct {
 	Namespace string `json:"namespace"`
 	ChartName string `json:"chartName"`
+	Revision  int    `json:"revision,omitempty"`
 }
 
 // GitServerInfo contains information Zarf uses to communicate with a git repository to push/pull repositories to.
@@ -16,23 +16,35 @@ type ZarfPackage struct {
 
 // ZarfMetadata lists information about the current ZarfPackage.
 type ZarfMetadata struct {
-	Name         string `json:"name" jsonschema:"description=Name to identify this Zarf package,pattern=^[a-z0-9\\-]+$"`
-	Description  string `json:"description,omitempty" jsonschema:"description=Additional information about this package"`
-	Version      string `json:"version,omitempty" jsonschema:"description=Generic string to track the package version by a package author"`
-	URL          string `json:"url,omitempty" jsonschema:"description=Link to package information when online"`
-	Image        string `json:"image,omitempty" jsonschema:"description=An image URL to embed in this package for future Zarf UI listing"`
-	Uncompressed bool   `json:"uncompressed,omitempty" jsonschema:"description=Disable compression of this package"`
-	Architecture string `json:"architecture,omitempty" jsonschema:"description=The target cluster architecture of this package"`
-	YOLO         bool   `json:"yolo,omitempty" jsonschema:"description=Yaml OnLy Online (YOLO): True enables deploying a Zarf package without first running zarf init against the cluster. This is ideal for connected environments where you want to use existing VCS and container registries."`
+	Name         string                   `json:"name" jsonschema:"description=Name to identify this Zarf package,pattern=^[a-z0-9\\-]+$"`
+	Description  string                   `json:"description,omitempty" jsonschema:"description=Additional information about this package"`
+	Version      string                   `json:"version,omitempty" jsonschema:"description=Generic string to track the package version by a package author"`
+	URL          string                   `json:"url,omitempty" jsonschema:"description=Link to package information when online"`
+	Image        string                   `json:"image,omitempty" jsonschema:"description=An image URL to embed in this package for future Zarf UI listing"`
+	Uncompressed bool                     `json:"uncompressed,omitempty" jsonschema:"description=Disable compression of this package"`
+	Architecture string                   `json:"architecture,omitempty" jsonschema:"description=The target cluster architecture of this package"`
+	YOLO         bool                     `json:"yolo,omitempty" jsonschema:"description=Yaml OnLy Online (YOLO): True enables deploying a Zarf package without first running zarf init against the cluster. This is ideal for connected environments where you want to use existing VCS and container registries."`
+	Requires     []ZarfPackageRequirement `json:"requires,omitempty" jsonschema:"description=Other Zarf packages that must already be deployed to the cluster before this package is deployed"`
+}
+
+// ZarfPackageRequirement is another Zarf package that has to be deployed before a package.
+type ZarfPackageRequirement struct {
+	Name    string `json:"name" jsonschema:"description=The name of the required package,pattern=^[a-z0-9\\-]+$"`
+	Version string `json:"version,omitempty" jsonschema:"description=A semver constraint the version of the deployed package must satisfy (i.e. ^1.2.0 or >1.0.0 <2.0.0)"`
 }
 
 // ZarfBuildData is written during the packager.Create() operation to track details of the created package.
 type ZarfBuildData struct {
-	Terminal     string `json:"terminal"`
-	User         string `json:"user"`
-	Architecture string `json:"architecture"`
-	Timestamp    string `json:"timestamp"`
-	Version      string `json:"version"`
+	Terminal          string `json:"terminal"`
+	User              string `json:"user"`
+	Architecture      string `json:"architecture"`
+	Timestamp         string `json:"timestamp"`
+	Version           string `json:"version"`
+	AggregateChecksum string `json:"aggregateChecksum,omitempty" jsonschema:"description=The SHA256 checksum of the checksums.txt file that lists the checksum of every file in the package"`
+
+	Differential               bool     `json:"differential,omitempty" jsonschema:"description=Whether this package only contains the images and repos that changed since a previous version of the package"`
+	DifferentialPackageVersion string   `json:"differentialPackageVersion,omitempty" jsonschema:"description=The version of the package this differential package was built against"`
+	DifferentialMissing        []string `json:"differentialMissing,omitempty" jsonschema:"description=The images left out of this differential package because they are unchanged since the version it was built against"`
 }
 
 // ZarfPackageVariable are variables that can be used to dynamically template K8s resources.
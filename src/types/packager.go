@@ -12,6 +12,12 @@ type PackagerConfig struct {
 	// DeployOpts tracks user-defined values for the active deployment
 	DeployOpts ZarfDeployOptions
 
+	// PublishOpts tracks user-defined values for publishing a package to an OCI registry
+	PublishOpts ZarfPublishOptions
+
+	// RemoveOpts tracks user-defined values for removing a deployed package
+	RemoveOpts ZarfRemoveOptions
+
 	// InitOpts tracks user-defined values for the active Zarf initialization.
 	InitOpts ZarfInitOptions
 
@@ -13,12 +13,16 @@ type ZarfCommonOptions struct {
 
 // ZarfDeployOptions tracks the user-defined preferences during a package deployment.
 type ZarfDeployOptions struct {
-	Insecure     bool              `json:"insecure" jsonschema:"description=Allow insecure connections for remote packages"`
-	Shasum       string            `json:"shasum" jsonschema:"description=The SHA256 checksum of the package to deploy"`
-	PackagePath  string            `json:"packagePath" jsonschema:"description=Location where a Zarf package to deploy can be found"`
-	Components   string            `json:"components" jsonschema:"description=Comma separated list of optional components to deploy"`
-	SGetKeyPath  string            `json:"sGetKeyPath" jsonschema:"description=Location where the public key component of a cosign key-pair can be found"`
-	SetVariables map[string]string `json:"setVariables" jsonschema:"description=Key-Value map of variable names and their corresponding values that will be used to template against the Zarf package being used"`
+	Insecure                bool              `json:"insecure" jsonschema:"description=Allow insecure connections for remote packages"`
+	PlainHTTP               bool              `json:"plainHTTP" jsonschema:"description=Allow plain HTTP connections to the registry of oci:// packages"`
+	Shasum                  string            `json:"shasum" jsonschema:"description=The SHA256 checksum of the package to deploy"`
+	PackagePath             string            `json:"packagePath" jsonschema:"description=Location where a Zarf package to deploy can be found"`
+	Components              string            `json:"components" jsonschema:"description=Comma separated list of optional components to deploy"`
+	SGetKeyPath             string            `json:"sGetKeyPath" jsonschema:"description=Location where the public key component of a cosign key-pair can be found"`
+	PublicKeyPath           string            `json:"publicKeyPath" jsonschema:"description=Location where the public key used to verify the package signature can be found"`
+	SkipSignatureValidation bool              `json:"skipSignatureValidation" jsonschema:"description=Skip validating the signature of a signed package when no public key is provided"`
+	Atomic                  bool              `json:"atomic" jsonschema:"description=Undo the charts installed by the deployment if any component fails to deploy"`
+	SetVariables            map[string]string `json:"setVariables" jsonschema:"description=Key-Value map of variable names and their corresponding values that will be used to template against the Zarf package being used"`
 }
 
 // ZarfInitOptions tracks the user-defined options during cluster initialization.
@@ -34,17 +38,39 @@ type ZarfInitOptions struct {
 	Components string `json:"components" jsonschema:"description=Comma separated list of optional components to deploy"`
 
 	StorageClass string `json:"storageClass" jsonschema:"description=StorageClass of the k8s cluster Zarf is initializing"`
+
+	AgentImagePolicy string `json:"agentImagePolicy" jsonschema:"description=Mode of the Zarf Agent policy for images not delivered by a Zarf package (enforce or audit)"`
+
+	StateEncryptionKeyPath string `json:"stateEncryptionKeyPath" jsonschema:"description=Path to the key used to encrypt the sensitive fields of the Zarf state"`
+
+	StateCRDs bool `json:"stateCRDs" jsonschema:"description=Store the Zarf state and deployed packages in Zarf custom resources"`
 }
 
 // ZarfCreateOptions tracks the user-defined options used to create the package.
 type ZarfCreateOptions struct {
-	SkipSBOM         bool              `json:"skipSBOM" jsonschema:"description=Disable the generation of SBOM materials during package creation"`
-	Insecure         bool              `json:"insecure" jsonschema:"description=Disable the need for shasum validations when pulling down files from the internet"`
-	OutputDirectory  string            `json:"outputDirectory" jsonschema:"description=Location where the finalized Zarf package will be placed"`
-	ViewSBOM         bool              `json:"sbom" jsonschema:"description=Whether to pause to allow for viewing the SBOM post-creation"`
-	SBOMOutputDir    string            `json:"sbomOutput" jsonschema:"description=Location to output an SBOM into after package creation"`
-	SetVariables     map[string]string `json:"setVariables" jsonschema:"description=Key-Value map of variable names and their corresponding values that will be used to template against the Zarf package being used"`
-	MaxPackageSizeMB int               `json:"maxPackageSizeMB" jsonschema:"description=Size of chunks to use when splitting a zarf package into multiple files in megabytes"`
+	SkipSBOM           bool              `json:"skipSBOM" jsonschema:"description=Disable the generation of SBOM materials during package creation"`
+	Insecure           bool              `json:"insecure" jsonschema:"description=Disable the need for shasum validations when pulling down files from the internet"`
+	OutputDirectory    string            `json:"outputDirectory" jsonschema:"description=Location where the finalized Zarf package will be placed"`
+	ViewSBOM           bool              `json:"sbom" jsonschema:"description=Whether to pause to allow for viewing the SBOM post-creation"`
+	SBOMOutputDir      string            `json:"sbomOutput" jsonschema:"description=Location to output an SBOM into after package creation"`
+	SetVariables       map[string]string `json:"setVariables" jsonschema:"description=Key-Value map of variable names and their corresponding values that will be used to template against the Zarf package being used"`
+	MaxPackageSizeMB   int               `json:"maxPackageSizeMB" jsonschema:"description=Size of chunks to use when splitting a zarf package into multiple files in megabytes"`
+	SigningKeyPath     string            `json:"signingKeyPath" jsonschema:"description=Location where the private key used to sign the package can be found"`
+	SigningKeyPassword string            `json:"signingKeyPassword" jsonschema:"description=Password to the private key used to sign the package"`
+
+	DifferentialPackagePath string `json:"differentialPackagePath" jsonschema:"description=Path to a previously built version of the package to create a differential package against"`
+}
+
+// ZarfPublishOptions tracks the user-defined options used to publish a package to an OCI registry.
+type ZarfPublishOptions struct {
+	PackagePath string `json:"packagePath" jsonschema:"description=Location where the Zarf package to publish can be found"`
+	Repository  string `json:"repository" jsonschema:"description=The oci:// URL of the repository the package will be published under"`
+	Insecure    bool   `json:"insecure" jsonschema:"description=Allow plain HTTP connections to the registry"`
+}
+
+// ZarfRemoveOptions tracks the user-defined options used to remove a package.
+type ZarfRemoveOptions struct {
+	Force bool `json:"force" jsonschema:"description=Remove components even if other deployed components depend on them"`
 }
 
 // ZarfPartialPackageData contains info about a partial package.
@@ -63,6 +89,30 @@ type ConnectString struct {
 // ConnectStrings is a map of connect names to connection information.
 type ConnectStrings map[string]ConnectString
 
+// DeployReport summarizes the result of a package deployment for other tools to consume.
+type DeployReport struct {
+	Name            string                  `json:"name"`
+	Version         string                  `json:"version,omitempty"`
+	Success         bool                    `json:"success"`
+	Error           string                  `json:"error,omitempty"`
+	DurationSeconds float64                 `json:"durationSeconds"`
+	Components      []DeployReportComponent `json:"components"`
+	ConnectStrings  ConnectStrings          `json:"connectStrings,omitempty"`
+}
+
+// DeployReportComponent summarizes the deployment of a single component of a package.
+type DeployReportComponent struct {
+	Name            string                  `json:"name"`
+	Success         bool                    `json:"success"`
+	Error           string                  `json:"error,omitempty"`
+	DurationSeconds float64                 `json:"durationSeconds"`
+	InstalledCharts []InstalledChart        `json:"installedCharts,omitempty"`
+	ImagesPushed    []string                `json:"imagesPushed,omitempty"`
+	ReposPushed     []string                `json:"reposPushed,omitempty"`
+	ChartsPushed    []string                `json:"chartsPushed,omitempty"`
+	DataInjections  []DeployedDataInjection `json:"dataInjections,omitempty"`
+}
+
 // ComponentSBOM contains information related to the files SBOM'ed from a component.
 type ComponentSBOM struct {
 	Files         []string
@@ -82,11 +132,12 @@ type ComponentPaths struct {
 
 // TempPaths is a struct that represents all of the subdirectories for a Zarf package.
 type TempPaths struct {
-	Base         string
-	InjectBinary string
-	SeedImage    string
-	Images       string
-	Components   string
-	Sboms        string
-	ZarfYaml     string
+	Base           string
+	InjectBinary   string
+	SeedImage      string
+	Images         string
+	ImageManifests string
+	Components     string
+	Sboms          string
+	ZarfYaml       string
 }
@@ -56,7 +56,26 @@ export interface ZarfPackage {
  * Zarf-generated package build data
  */
 export interface ZarfBuildData {
+    /**
+     * The SHA256 checksum of the checksums.txt file that lists the checksum of every file in
+     * the package
+     */
+    aggregateChecksum?: string;
     architecture: string;
+    /**
+     * Whether this package only contains the images and repos that changed since a previous
+     * version of the package
+     */
+    differential?: boolean;
+    /**
+     * The images left out of this differential package because they are unchanged since the
+     * version it was built against
+     */
+    differentialMissing?: string[];
+    /**
+     * The version of the package this differential package was built against
+     */
+    differentialPackageVersion?: string;
     terminal:     string;
     timestamp:    string;
     user:         string;
@@ -80,6 +99,11 @@ export interface ZarfComponent {
      * Determines the default Y/N state for installing this component on package deploy
      */
     default?: boolean;
+    /**
+     * Names of the components this component needs; they are deployed first and included
+     * automatically when this component is selected
+     */
+    dependsOn?: string[];
     /**
      * Message to include during package deploy describing the purpose of this component
      */
@@ -121,6 +145,11 @@ export interface ZarfComponent {
      * Custom commands to run before or after package deployment
      */
     scripts?: ZarfComponentScripts;
+    /**
+     * Resource conditions to wait for after the charts and manifests of this component are
+     * deployed
+     */
+    wait?: ZarfComponentWait[];
 }
 
 export interface ZarfChart {
@@ -145,13 +174,18 @@ export interface ZarfChart {
      * Wait for chart resources to be ready before continuing
      */
     noWait?: boolean;
+    /**
+     * Push the chart to the Zarf registry as an OCI artifact during deploy so in-cluster tools
+     * can reference it
+     */
+    pushToRegistry?: boolean;
     /**
      * The name of the release to create; defaults to the name of the chart
      */
     releaseName?: string;
     /**
-     * The URL of the chart repository or git url if the chart is using a git repo instead of
-     * helm repo
+     * The URL of the chart repository; the oci:// URL of the chart or git url if the chart is
+     * using a git repo instead of helm repo
      */
     url?: string;
     /**
@@ -168,9 +202,13 @@ export interface ZarfChart {
 export interface ZarfDataInjection {
     /**
      * Compress the data before transmitting using gzip.  Note: this requires support for
-     * tar/gzip locally and in the target image.
+     * tar/gzip in the target image.
      */
     compress?: boolean;
+    /**
+     * Maximum number of attempts to inject the data before failing (default 10)
+     */
+    maxRetries?: number;
     /**
      * A path to a local folder or file to inject into the given target pod + container
      */
@@ -179,6 +217,10 @@ export interface ZarfDataInjection {
      * The target pod + container to inject the data into
      */
     target: ZarfContainerTarget;
+    /**
+     * Timeout in seconds to wait for the data injection to complete (default 900)
+     */
+    timeoutSeconds?: number;
 }
 
 /**
@@ -285,7 +327,8 @@ export interface ZarfComponentOnlyCluster {
      */
     architecture?: Architecture;
     /**
-     * Future use
+     * Only deploy to clusters of the given distros (i.e. k3s or eks); prefix a distro with ! to
+     * exclude it and use * as a wildcard
      */
     distros?: string[];
 }
@@ -337,6 +380,43 @@ export interface ZarfComponentScripts {
     timeoutSeconds?: number;
 }
 
+export interface ZarfComponentWait {
+    /**
+     * The status condition that must be True on the resources (i.e. Ready or Available or
+     * Complete)
+     */
+    condition?: string;
+    /**
+     * A JSONPath expression to evaluate against the resources (i.e. {.status.phase})
+     */
+    jsonPath?: string;
+    /**
+     * The kind of resource to wait for (i.e. Deployment or jobs.batch)
+     */
+    kind: string;
+    /**
+     * The name of the resource to wait for (cannot be used with selector)
+     */
+    name?: string;
+    /**
+     * The namespace of the resources to wait for (a name defaults to the default namespace and
+     * a selector matches all namespaces if empty)
+     */
+    namespace?: string;
+    /**
+     * A label selector matching the resources to wait for (cannot be used with name)
+     */
+    selector?: string;
+    /**
+     * Timeout in seconds to wait for the resources (default 300)
+     */
+    timeoutSeconds?: number;
+    /**
+     * The value the JSONPath expression must equal (i.e. Running)
+     */
+    value?: string;
+}
+
 export interface ZarfPackageConstant {
     /**
      * A description of the constant to explain its purpose on package create or deploy
@@ -381,6 +461,11 @@ export interface ZarfMetadata {
      * Name to identify this Zarf package
      */
     name: string;
+    /**
+     * Other Zarf packages that must already be deployed to the cluster before this package is
+     * deployed
+     */
+    requires?: ZarfPackageRequirement[];
     /**
      * Disable compression of this package
      */
@@ -401,6 +486,18 @@ export interface ZarfMetadata {
     yolo?: boolean;
 }
 
+export interface ZarfPackageRequirement {
+    /**
+     * The name of the required package
+     */
+    name: string;
+    /**
+     * A semver constraint the version of the deployed package must satisfy (i.e. ^1.2.0 or
+     * >1.0.0 <2.0.0)
+     */
+    version?: string;
+}
+
 export interface ZarfPackageVariable {
     /**
      * The default value to use for the variable
@@ -428,6 +525,10 @@ export interface ClusterSummary {
 }
 
 export interface ZarfState {
+    /**
+     * Mode of the Zarf Agent policy for images not delivered by a Zarf package (enforce or audit)
+     */
+    agentImagePolicy?: string;
     agentTLS: GeneratedPKI;
     /**
      * Machine architecture of the k8s node(s)
@@ -437,6 +538,10 @@ export interface ZarfState {
      * K8s distribution of the cluster Zarf was deployed to
      */
     distro: string;
+    /**
+     * Settings used to encrypt the sensitive fields of the state at rest
+     */
+    encryption?: StateEncryption;
     /**
      * Information about the repository Zarf is configured to use
      */
@@ -462,6 +567,16 @@ export interface GeneratedPKI {
     key:  string;
 }
 
+/**
+ * Settings used to encrypt the sensitive fields of the state at rest
+ */
+export interface StateEncryption {
+    /**
+     * Provider of the key that wraps the state data key (local)
+     */
+    provider?: string;
+}
+
 /**
  * Information about the repository Zarf is configured to use
  *
@@ -554,17 +669,28 @@ export interface DeployedPackage {
     cliVersion:         string;
     data:               ZarfPackage;
     deployedComponents: DeployedComponent[];
+    generation?:        number;
     name:               string;
 }
 
 export interface DeployedComponent {
+    dataInjections?: DeployedDataInjection[];
     installedCharts: InstalledChart[];
     name:            string;
+    status?:         string;
+}
+
+export interface DeployedDataInjection {
+    error?: string;
+    source: string;
+    status: string;
+    target: string;
 }
 
 export interface InstalledChart {
     chartName: string;
     namespace: string;
+    revision?: number;
 }
 
 export interface ZarfCommonOptions {
@@ -584,6 +710,11 @@ export interface ZarfCommonOptions {
 }
 
 export interface ZarfCreateOptions {
+    /**
+     * Path to a previously built version of the package to create a differential package
+     * against
+     */
+    differentialPackagePath: string;
     /**
      * Disable the need for shasum validations when pulling down files from the internet
      */
@@ -609,6 +740,14 @@ export interface ZarfCreateOptions {
      * template against the Zarf package being used
      */
     setVariables: { [key: string]: string };
+    /**
+     * Password to the private key used to sign the package
+     */
+    signingKeyPassword: string;
+    /**
+     * Location where the private key used to sign the package can be found
+     */
+    signingKeyPath: string;
     /**
      * Disable the generation of SBOM materials during package creation
      */
@@ -616,6 +755,10 @@ export interface ZarfCreateOptions {
 }
 
 export interface ZarfDeployOptions {
+    /**
+     * Undo the charts installed by the deployment if any component fails to deploy
+     */
+    atomic: boolean;
     /**
      * Comma separated list of optional components to deploy
      */
@@ -628,6 +771,14 @@ export interface ZarfDeployOptions {
      * Location where a Zarf package to deploy can be found
      */
     packagePath: string;
+    /**
+     * Allow plain HTTP connections to the registry of oci:// packages
+     */
+    plainHTTP: boolean;
+    /**
+     * Location where the public key used to verify the package signature can be found
+     */
+    publicKeyPath: string;
     /**
      * Key-Value map of variable names and their corresponding values that will be used to
      * template against the Zarf package being used
@@ -641,9 +792,17 @@ export interface ZarfDeployOptions {
      * The SHA256 checksum of the package to deploy
      */
     shasum: string;
+    /**
+     * Skip validating the signature of a signed package when no public key is provided
+     */
+    skipSignatureValidation: boolean;
 }
 
 export interface ZarfInitOptions {
+    /**
+     * Mode of the Zarf Agent policy for images not delivered by a Zarf package (enforce or audit)
+     */
+    agentImagePolicy: string;
     /**
      * Indicates if Zarf was initialized while deploying its own k8s cluster
      */
@@ -660,6 +819,14 @@ export interface ZarfInitOptions {
      * Information about the registry Zarf is going to be using
      */
     registryInfo: RegistryInfo;
+    /**
+     * Store the Zarf state and deployed packages in Zarf custom resources
+     */
+    stateCRDs: boolean;
+    /**
+     * Path to the key used to encrypt the sensitive fields of the Zarf state
+     */
+    stateEncryptionKeyPath: string;
     /**
      * StorageClass of the k8s cluster Zarf is initializing
      */
@@ -856,7 +1023,11 @@ const typeMap: any = {
         { json: "variables", js: "variables", typ: u(undefined, a(r("ZarfPackageVariable"))) },
     ], false),
     "ZarfBuildData": o([
+        { json: "aggregateChecksum", js: "aggregateChecksum", typ: u(undefined, "") },
         { json: "architecture", js: "architecture", typ: "" },
+        { json: "differential", js: "differential", typ: u(undefined, true) },
+        { json: "differentialMissing", js: "differentialMissing", typ: u(undefined, a("")) },
+        { json: "differentialPackageVersion", js: "differentialPackageVersion", typ: u(undefined, "") },
         { json: "terminal", js: "terminal", typ: "" },
         { json: "timestamp", js: "timestamp", typ: "" },
         { json: "user", js: "user", typ: "" },
@@ -867,6 +1038,7 @@ const typeMap: any = {
         { json: "cosignKeyPath", js: "cosignKeyPath", typ: u(undefined, "") },
         { json: "dataInjections", js: "dataInjections", typ: u(undefined, a(r("ZarfDataInjection"))) },
         { json: "default", js: "default", typ: u(undefined, true) },
+        { json: "dependsOn", js: "dependsOn", typ: u(undefined, a("")) },
         { json: "description", js: "description", typ: u(undefined, "") },
         { json: "files", js: "files", typ: u(undefined, a(r("ZarfFile"))) },
         { json: "group", js: "group", typ: u(undefined, "") },
@@ -878,6 +1050,7 @@ const typeMap: any = {
         { json: "repos", js: "repos", typ: u(undefined, a("")) },
         { json: "required", js: "required", typ: u(undefined, true) },
         { json: "scripts", js: "scripts", typ: u(undefined, r("ZarfComponentScripts")) },
+        { json: "wait", js: "wait", typ: u(undefined, a(r("ZarfComponentWait"))) },
     ], false),
     "ZarfChart": o([
         { json: "gitPath", js: "gitPath", typ: u(undefined, "") },
@@ -885,6 +1058,7 @@ const typeMap: any = {
         { json: "name", js: "name", typ: "" },
         { json: "namespace", js: "namespace", typ: "" },
         { json: "noWait", js: "noWait", typ: u(undefined, true) },
+        { json: "pushToRegistry", js: "pushToRegistry", typ: u(undefined, true) },
         { json: "releaseName", js: "releaseName", typ: u(undefined, "") },
         { json: "url", js: "url", typ: u(undefined, "") },
         { json: "valuesFiles", js: "valuesFiles", typ: u(undefined, a("")) },
@@ -892,8 +1066,10 @@ const typeMap: any = {
     ], false),
     "ZarfDataInjection": o([
         { json: "compress", js: "compress", typ: u(undefined, true) },
+        { json: "maxRetries", js: "maxRetries", typ: u(undefined, 0) },
         { json: "source", js: "source", typ: "" },
         { json: "target", js: "target", typ: r("ZarfContainerTarget") },
+        { json: "timeoutSeconds", js: "timeoutSeconds", typ: u(undefined, 0) },
     ], false),
     "ZarfContainerTarget": o([
         { json: "container", js: "container", typ: "" },
@@ -936,6 +1112,16 @@ const typeMap: any = {
         { json: "showOutput", js: "showOutput", typ: u(undefined, true) },
         { json: "timeoutSeconds", js: "timeoutSeconds", typ: u(undefined, 0) },
     ], false),
+    "ZarfComponentWait": o([
+        { json: "condition", js: "condition", typ: u(undefined, "") },
+        { json: "jsonPath", js: "jsonPath", typ: u(undefined, "") },
+        { json: "kind", js: "kind", typ: "" },
+        { json: "name", js: "name", typ: u(undefined, "") },
+        { json: "namespace", js: "namespace", typ: u(undefined, "") },
+        { json: "selector", js: "selector", typ: u(undefined, "") },
+        { json: "timeoutSeconds", js: "timeoutSeconds", typ: u(undefined, 0) },
+        { json: "value", js: "value", typ: u(undefined, "") },
+    ], false),
     "ZarfPackageConstant": o([
         { json: "description", js: "description", typ: u(undefined, "") },
         { json: "name", js: "name", typ: "" },
@@ -946,11 +1132,16 @@ const typeMap: any = {
         { json: "description", js: "description", typ: u(undefined, "") },
         { json: "image", js: "image", typ: u(undefined, "") },
         { json: "name", js: "name", typ: "" },
+        { json: "requires", js: "requires", typ: u(undefined, a(r("ZarfPackageRequirement"))) },
         { json: "uncompressed", js: "uncompressed", typ: u(undefined, true) },
         { json: "url", js: "url", typ: u(undefined, "") },
         { json: "version", js: "version", typ: u(undefined, "") },
         { json: "yolo", js: "yolo", typ: u(undefined, true) },
     ], false),
+    "ZarfPackageRequirement": o([
+        { json: "name", js: "name", typ: "" },
+        { json: "version", js: "version", typ: u(undefined, "") },
+    ], false),
     "ZarfPackageVariable": o([
         { json: "default", js: "default", typ: u(undefined, "") },
         { json: "description", js: "description", typ: u(undefined, "") },
@@ -964,9 +1155,11 @@ const typeMap: any = {
         { json: "zarfState", js: "zarfState", typ: r("ZarfState") },
     ], false),
     "ZarfState": o([
+        { json: "agentImagePolicy", js: "agentImagePolicy", typ: u(undefined, "") },
         { json: "agentTLS", js: "agentTLS", typ: r("GeneratedPKI") },
         { json: "architecture", js: "architecture", typ: "" },
         { json: "distro", js: "distro", typ: "" },
+        { json: "encryption", js: "encryption", typ: u(undefined, r("StateEncryption")) },
         { json: "gitServer", js: "gitServer", typ: r("GitServerInfo") },
         { json: "loggingSecret", js: "loggingSecret", typ: "" },
         { json: "registryInfo", js: "registryInfo", typ: r("RegistryInfo") },
@@ -978,6 +1171,9 @@ const typeMap: any = {
         { json: "cert", js: "cert", typ: "" },
         { json: "key", js: "key", typ: "" },
     ], false),
+    "StateEncryption": o([
+        { json: "provider", js: "provider", typ: u(undefined, "") },
+    ], false),
     "GitServerInfo": o([
         { json: "address", js: "address", typ: "" },
         { json: "internalServer", js: "internalServer", typ: true },
@@ -1004,15 +1200,25 @@ const typeMap: any = {
         { json: "cliVersion", js: "cliVersion", typ: "" },
         { json: "data", js: "data", typ: r("ZarfPackage") },
         { json: "deployedComponents", js: "deployedComponents", typ: a(r("DeployedComponent")) },
+        { json: "generation", js: "generation", typ: u(undefined, 0) },
         { json: "name", js: "name", typ: "" },
     ], false),
     "DeployedComponent": o([
+        { json: "dataInjections", js: "dataInjections", typ: u(undefined, a(r("DeployedDataInjection"))) },
         { json: "installedCharts", js: "installedCharts", typ: a(r("InstalledChart")) },
         { json: "name", js: "name", typ: "" },
+        { json: "status", js: "status", typ: u(undefined, "") },
+    ], false),
+    "DeployedDataInjection": o([
+        { json: "error", js: "error", typ: u(undefined, "") },
+        { json: "source", js: "source", typ: "" },
+        { json: "status", js: "status", typ: "" },
+        { json: "target", js: "target", typ: "" },
     ], false),
     "InstalledChart": o([
         { json: "chartName", js: "chartName", typ: "" },
         { json: "namespace", js: "namespace", typ: "" },
+        { json: "revision", js: "revision", typ: u(undefined, 0) },
     ], false),
     "ZarfCommonOptions": o([
         { json: "cachePath", js: "cachePath", typ: "" },
@@ -1020,27 +1226,37 @@ const typeMap: any = {
         { json: "tempDirectory", js: "tempDirectory", typ: "" },
     ], false),
     "ZarfCreateOptions": o([
+        { json: "differentialPackagePath", js: "differentialPackagePath", typ: "" },
         { json: "insecure", js: "insecure", typ: true },
         { json: "maxPackageSizeMB", js: "maxPackageSizeMB", typ: 0 },
         { json: "outputDirectory", js: "outputDirectory", typ: "" },
         { json: "sbom", js: "sbom", typ: true },
         { json: "sbomOutput", js: "sbomOutput", typ: "" },
         { json: "setVariables", js: "setVariables", typ: m("") },
+        { json: "signingKeyPassword", js: "signingKeyPassword", typ: "" },
+        { json: "signingKeyPath", js: "signingKeyPath", typ: "" },
         { json: "skipSBOM", js: "skipSBOM", typ: true },
     ], false),
     "ZarfDeployOptions": o([
+        { json: "atomic", js: "atomic", typ: true },
         { json: "components", js: "components", typ: "" },
         { json: "insecure", js: "insecure", typ: true },
         { json: "packagePath", js: "packagePath", typ: "" },
+        { json: "plainHTTP", js: "plainHTTP", typ: true },
+        { json: "publicKeyPath", js: "publicKeyPath", typ: "" },
         { json: "setVariables", js: "setVariables", typ: m("") },
         { json: "sGetKeyPath", js: "sGetKeyPath", typ: "" },
         { json: "shasum", js: "shasum", typ: "" },
+        { json: "skipSignatureValidation", js: "skipSignatureValidation", typ: true },
     ], false),
     "ZarfInitOptions": o([
+        { json: "agentImagePolicy", js: "agentImagePolicy", typ: "" },
         { json: "applianceMode", js: "applianceMode", typ: true },
         { json: "components", js: "components", typ: "" },
         { json: "gitServer", js: "gitServer", typ: r("GitServerInfo") },
         { json: "registryInfo", js: "registryInfo", typ: r("RegistryInfo") },
+        { json: "stateCRDs", js: "stateCRDs", typ: true },
+        { json: "stateEncryptionKeyPath", js: "stateEncryptionKeyPath", typ: "" },
         { json: "storageClass", js: "storageClass", typ: "" },
     ], false),
     "Architecture": [